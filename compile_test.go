@@ -0,0 +1,107 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Item struct {
+	SKU   string `validate:"len:8"`
+	Count int    `validate:"min:1;max:100"`
+}
+
+type Order struct {
+	ID    string  `validate:"required;max:36"`
+	Email string  `validate:"email"`
+	Items []Item  `validate:"minlen:1;dive"`
+	Note  *string `validate:"max:140"`
+	Tier  int     `validate:"in:1,2,3"`
+}
+
+func newOrder() Order {
+	return Order{
+		ID:    "42",
+		Email: "alex@example.com",
+		Items: []Item{{"SKU00001", 2}, {"SKU00002", 10}},
+		Note:  ptr("leave at the door"),
+		Tier:  2,
+	}
+}
+
+func TestCompile(t *testing.T) {
+	c, err := Compile(reflect.TypeOf(&Order{}))
+	assert.NoError(t, err)
+
+	order := newOrder()
+	assert.NoError(t, c.Validate(order))
+	assert.NoError(t, c.Validate(&order))
+
+	order.Items[1].Count = 0
+	order.Tier = 4
+	err = c.Validate(order)
+	assert.EqualError(t, err, Validate(order).Error())
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 2)
+	assert.Equal(t, "Items[1].Count", e[0].FieldName)
+	assert.Equal(t, "Tier", e[1].FieldName)
+
+	assert.ErrorIs(t, c.Validate(Item{}), ErrNotStruct)
+	assert.ErrorIs(t, c.Validate(1), ErrNotStruct)
+	assert.ErrorIs(t, c.Validate((*Order)(nil)), ErrNilPointer)
+}
+
+func TestCompileErrors(t *testing.T) {
+	_, err := Compile(reflect.TypeOf(1))
+	assert.ErrorIs(t, err, ErrNotStruct)
+
+	type line struct {
+		Qty int `validate:"min:x"`
+	}
+	type cart struct {
+		Lines []line `validate:"dive"`
+		Code  string `validate:"unknown"`
+		note  string `validate:"max:10"`
+	}
+
+	_, err = Compile(reflect.TypeOf(cart{}))
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	assert.Equal(t, "validator.line.Qty", e[0].FieldName)
+	assert.ErrorIs(t, e[0], ErrInvalidValidatorSyntax)
+	assert.Equal(t, "validator.cart.Code", e[1].FieldName)
+	assert.Equal(t, "validator.cart.note", e[2].FieldName)
+	assert.ErrorIs(t, e[2], ErrValidateForUnexportedFields)
+}
+
+// BenchmarkValidate validates with tags parsed by the first call and cached, the path CompiledValidator is compared with.
+func BenchmarkValidate(b *testing.B) {
+	order := newOrder()
+	_ = Validate(order)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Validate(order)
+	}
+}
+
+func BenchmarkValidateUncached(b *testing.B) {
+	order := newOrder()
+	for i := 0; i < b.N; i++ {
+		_ = New().Validate(order)
+	}
+}
+
+func BenchmarkCompiledValidate(b *testing.B) {
+	order := newOrder()
+	c, err := Compile(reflect.TypeOf(order))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = c.Validate(order)
+	}
+}
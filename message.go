@@ -0,0 +1,111 @@
+package validator
+
+import (
+	"github.com/pkg/errors"
+)
+
+// MessageFunc builds the message of an error of the rule failed by the field, e.g. to translate it.
+// params hold the arguments of the constraint, see (*Validator).SetMessageFunc. When it returns
+// an empty string the default English message is used.
+type MessageFunc func(rule, field string, params map[string]any) string
+
+// SetMessageFunc sets fn as the message builder of Default, see (*Validator).SetMessageFunc.
+func SetMessageFunc(fn MessageFunc) {
+	Default.SetMessageFunc(fn)
+}
+
+// SetMessageFunc makes fn build messages of failed constraints, a nil fn restores the default messages.
+// The params passed to fn are keyed by the rule name, e.g. "max" holds the bound of max:10 as a float64
+// and "in" holds the in list as a []string. The exceptions are "schemes" of url, "version" of uuid and
+// "field" of eqfield, gtfield and ltfield. Rules without arguments, like required or email, have no params.
+// Tag syntax errors and errors of custom validators aren't passed to fn.
+func (v *Validator) SetMessageFunc(fn MessageFunc) {
+	v.validatorsMu.Lock()
+	defer v.validatorsMu.Unlock()
+
+	v.messageFunc = fn
+}
+
+func (v *Validator) lookupMessageFunc() MessageFunc {
+	v.validatorsMu.RLock()
+	defer v.validatorsMu.RUnlock()
+
+	return v.messageFunc
+}
+
+// failure returns the error of the rule failed by the field, msg is the default message.
+func (c Constraints) failure(fieldName, rule, msg string) ValidationError {
+	if fn := c.validator().lookupMessageFunc(); fn != nil {
+		if m := fn(rule, fieldName, c.params(rule)); m != "" {
+			msg = m
+		}
+	}
+	return ValidationError{Err: errors.New(msg), FieldName: fieldName, Rule: rule}
+}
+
+// params returns the arguments of the rule passed to a MessageFunc.
+func (c Constraints) params(rule string) map[string]any {
+	var value any
+	switch rule {
+	case "max":
+		value = *c.max
+	case "min":
+		value = *c.min
+	case "len":
+		value = c.len
+	case "gt":
+		value = *c.gt
+	case "lt":
+		value = *c.lt
+	case "multipleof":
+		value = *c.multipleOf
+	case "minlen":
+		value = c.minLen
+	case "maxlen":
+		value = c.maxLen
+	case "exactlen":
+		value = c.exactLen
+	case "in":
+		value = c.in
+	case "notin":
+		value = c.notin
+	case "strmin":
+		value = *c.strMin
+	case "strmax":
+		value = *c.strMax
+	case "contains":
+		value = c.contains
+	case "prefix":
+		value = c.prefix
+	case "suffix":
+		value = c.suffix
+	case "regexp":
+		value = c.pattern.String()
+	case "eq":
+		value = *c.eq
+	case "after":
+		value = *c.after
+	case "before":
+		value = *c.before
+	case "url":
+		if c.urlSchemes == nil {
+			return nil
+		}
+		return map[string]any{"schemes": c.urlSchemes}
+	case "uuid":
+		if c.uuidVersion == 0 {
+			return nil
+		}
+		return map[string]any{"version": c.uuidVersion}
+	case "eqfield", "gtfield", "ltfield":
+		for _, ref := range c.fieldRefs {
+			if ref.rule == rule {
+				return map[string]any{"field": ref.fieldName}
+			}
+		}
+		return nil
+	default:
+		return nil
+	}
+	return map[string]any{rule: value}
+}
@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// french translates some of the rules, the others keep the default messages
+func french(rule, field string, params map[string]any) string {
+	switch rule {
+	case "required":
+		return fmt.Sprintf("le champ %s est obligatoire", field)
+	case "max":
+		return fmt.Sprintf("le champ %s ne doit pas dépasser %v", field, params["max"])
+	case "in":
+		return fmt.Sprintf("le champ %s doit être l'une des valeurs %v", field, params["in"])
+	case "eqfield":
+		return fmt.Sprintf("le champ %s doit être égal à %v", field, params["field"])
+	}
+	return ""
+}
+
+func TestSetMessageFunc(t *testing.T) {
+	type address struct {
+		City string `validate:"required"`
+	}
+	type user struct {
+		Name     string   `validate:"required"`
+		Age      int      `validate:"max:120"`
+		Lang     string   `validate:"in:fr,en"`
+		Email    string   `validate:"email"`
+		Password string   `validate:"min:8"`
+		Confirm  string   `validate:"eqfield:Password"`
+		Tags     []string `validate:"max:3"`
+		Address  address
+	}
+
+	v := New()
+	v.SetMessageFunc(french)
+
+	err := v.Validate(user{Age: 130, Lang: "de", Email: "x", Password: "12345678", Confirm: "1", Tags: []string{"abcd"}})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 7)
+	assert.Equal(t, "le champ Name est obligatoire", e[0].Err.Error())
+	assert.Equal(t, "required", e[0].Rule)
+	assert.Equal(t, "le champ Age ne doit pas dépasser 120", e[1].Err.Error())
+	assert.Equal(t, "le champ Lang doit être l'une des valeurs [fr en]", e[2].Err.Error())
+	assert.Equal(t, "value is not a valid email address", e[3].Err.Error())
+	assert.Equal(t, "le champ Confirm doit être égal à Password", e[4].Err.Error())
+	assert.Equal(t, "le champ Tags[0] ne doit pas dépasser 3", e[5].Err.Error())
+	assert.Equal(t, "le champ Address.City est obligatoire", e[6].Err.Error())
+
+	// syntax errors keep their messages
+	err = v.ValidateField("abc", "max:x")
+	assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+
+	// Default isn't affected until its own function is set
+	err = ValidateField("", "required")
+	assert.Equal(t, "value is required", err.Error())
+
+	SetMessageFunc(french)
+	defer SetMessageFunc(nil)
+	err = ValidateField("", "required")
+	assert.Equal(t, "le champ  est obligatoire", err.Error())
+}
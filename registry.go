@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+)
+
+// ValidatorFunc checks a field value and returns an error describing why the value is invalid.
+type ValidatorFunc func(val reflect.Value) error
+
+// ContextValidatorFunc works like ValidatorFunc, but also gets the context passed to ValidateContext,
+// e.g. to cancel a database lookup. Validate passes context.Background().
+type ContextValidatorFunc func(ctx context.Context, val reflect.Value) error
+
+type customValidator struct {
+	name string
+	fn   ContextValidatorFunc
+}
+
+// RegisterValidator registers fn in the registry of Default, see (*Validator).RegisterValidator.
+func RegisterValidator(name string, fn ValidatorFunc) {
+	Default.RegisterValidator(name, fn)
+}
+
+// RegisterValidator registers fn under the given name, the validator is referenced by the bare name
+// in a tag, e.g. `validate:"phone"`. Registering the same name again replaces the previous validator.
+// It panics when name is the name of a built-in constraint (max, min, len, in, required, ...),
+// as a tag referencing it would be ambiguous.
+func (v *Validator) RegisterValidator(name string, fn ValidatorFunc) {
+	v.RegisterContextValidator(name, func(_ context.Context, val reflect.Value) error {
+		return fn(val)
+	})
+}
+
+// RegisterContextValidator registers fn in the registry of Default, see (*Validator).RegisterContextValidator.
+func RegisterContextValidator(name string, fn ContextValidatorFunc) {
+	Default.RegisterContextValidator(name, fn)
+}
+
+// RegisterContextValidator works like RegisterValidator, but fn gets the context passed to ValidateContext.
+func (v *Validator) RegisterContextValidator(name string, fn ContextValidatorFunc) {
+	if builtinConstraints[name] {
+		panic("validator: can't register " + name + ", it's a built-in constraint")
+	}
+
+	v.validatorsMu.Lock()
+	defer v.validatorsMu.Unlock()
+
+	v.validators[name] = fn
+
+	// parsed tags may reference the validator by the name or fail on it as on an unknown one
+	v.cache.Range(func(key, _ any) bool {
+		v.cache.Delete(key)
+		return true
+	})
+}
+
+// builtinConstraints holds the names of constraints parsed by parseConstraint, including aliases,
+// and of the keys and endkeys markers.
+var builtinConstraints = map[string]bool{
+	"required": true, "omitempty": true, "dive": true, "nested": true, "default": true, "keys": true, "endkeys": true,
+	"max": true, "lte": true, "min": true, "gte": true, "gt": true, "lt": true, "range": true, "len": true,
+	"minlen": true, "maxlen": true, "exactlen": true, "len_min": true, "len_max": true, "unique": true,
+	"positive": true, "negative": true, "multipleof": true, "in": true, "oneof": true, "notin": true, "fold": true,
+	"email": true, "ip": true, "ipv4": true, "ipv6": true, "hostname": true, "fqdn": true, "url": true, "uuid": true, "json": true,
+	"alpha": true, "numeric": true, "alphanumeric": true, "lowercase": true, "uppercase": true, "trim": true,
+	"strmin": true, "strmax": true, "contains": true, "prefix": true, "suffix": true, "regexp": true,
+	"eq": true, "eqfield": true, "gtfield": true, "ltfield": true, "after": true, "before": true,
+}
+
+func (v *Validator) lookupValidator(name string) (ContextValidatorFunc, bool) {
+	v.validatorsMu.RLock()
+	defer v.validatorsMu.RUnlock()
+
+	fn, ok := v.validators[name]
+	return fn, ok
+}
+
+// RegisterTypeValidator registers fn for the type t in the registry of Default, see (*Validator).RegisterTypeValidator.
+func RegisterTypeValidator(t reflect.Type, fn ValidatorFunc) {
+	Default.RegisterTypeValidator(t, fn)
+}
+
+// RegisterTypeValidator registers fn for every exported field of the type t, or of a non-nil pointer to t,
+// the field doesn't need a tag. The validator is called in addition to the constraints from the tag,
+// failures are reported with the name of the type as the rule. Registering the same type again
+// replaces the previous validator.
+func (v *Validator) RegisterTypeValidator(t reflect.Type, fn ValidatorFunc) {
+	v.validatorsMu.Lock()
+	defer v.validatorsMu.Unlock()
+
+	v.typeValidators[t] = fn
+}
+
+func (v *Validator) lookupTypeValidator(t reflect.Type) (ValidatorFunc, bool) {
+	v.validatorsMu.RLock()
+	defer v.validatorsMu.RUnlock()
+
+	fn, ok := v.typeValidators[t]
+	return fn, ok
+}
+
+// checkTypeValidators calls the validator registered for the type of val, a nil pointer is skipped.
+func (v *Validator) checkTypeValidators(val reflect.Value, fieldName string, validationErrors ValidationErrors) ValidationErrors {
+	fn, ok := v.lookupTypeValidator(val.Type())
+	if !ok && val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+		fn, ok = v.lookupTypeValidator(val.Type())
+	}
+	if !ok {
+		return validationErrors
+	}
+
+	if err := fn(val); err != nil {
+		validationErrors = append(validationErrors, ValidationError{Err: err, FieldName: fieldName, Rule: val.Type().String()})
+	}
+	return validationErrors
+}
@@ -0,0 +1,200 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errNotPhone = errors.New("value is not a phone number")
+
+func TestRegisterValidator(t *testing.T) {
+	RegisterValidator("phone", func(val reflect.Value) error {
+		if !strings.HasPrefix(val.String(), "+") {
+			return errNotPhone
+		}
+		return nil
+	})
+	RegisterValidator("even", func(val reflect.Value) error {
+		if val.Len()%2 != 0 {
+			return errors.New("length must be even")
+		}
+		return nil
+	})
+
+	err := Validate(struct {
+		A string `validate:"phone"`
+		B string `validate:"phone;max:5"`
+		C []int  `validate:"even"`
+	}{
+		"+123",
+		"123456",
+		[]int{1, 2, 3},
+	})
+
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	assert.Equal(t, "B", e[0].FieldName)
+	assert.Equal(t, "phone", e[0].Rule)
+	assert.ErrorIs(t, e[0].Err, errNotPhone)
+	assert.Equal(t, "max", e[1].Rule)
+	assert.Equal(t, "C", e[2].FieldName)
+	assert.Equal(t, "even", e[2].Rule)
+
+	assert.NoError(t, Validate(struct {
+		A string `validate:"phone"`
+		C []int  `validate:"even"`
+	}{
+		"+123",
+		[]int{1, 2},
+	}))
+}
+
+func TestRegisterValidatorUnknown(t *testing.T) {
+	RegisterValidator("phone", func(val reflect.Value) error { return nil })
+
+	err := Validate(struct {
+		A string `validate:"unknown"`
+		B string `validate:"phone:us"`
+		C int    `validate:"mxa:5"`
+	}{})
+
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	for _, vErr := range e {
+		assert.ErrorIs(t, vErr.Err, ErrInvalidValidatorSyntax)
+	}
+	assert.Equal(t, "field: B err: unknown constraint 'phone': invalid validator syntax", e[1:2].Error())
+	assert.Equal(t, "mxa", e[2].Rule)
+}
+
+func TestRegisterValidatorBuiltinName(t *testing.T) {
+	v := New()
+	for _, name := range []string{"max", "required", "len_min", "oneof", "keys"} {
+		assert.Panics(t, func() { v.RegisterValidator(name, func(val reflect.Value) error { return nil }) }, name)
+	}
+	assert.Panics(t, func() {
+		v.RegisterContextValidator("email", func(_ context.Context, val reflect.Value) error { return nil })
+	})
+	assert.Empty(t, v.validators)
+}
+
+func TestValidatorRegistryIsolated(t *testing.T) {
+	type user struct {
+		Name string `validate:"upper"`
+	}
+
+	v := New()
+	err := v.Validate(user{"alex"})
+	assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+
+	// registering a validator drops tags parsed before
+	v.RegisterValidator("upper", func(val reflect.Value) error {
+		if val.String() != strings.ToUpper(val.String()) {
+			return errors.New("value must be upper case")
+		}
+		return nil
+	})
+	err = v.Validate(user{"alex"})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 1)
+	assert.Equal(t, "upper", e[0].Rule)
+	assert.NoError(t, v.Validate(user{"ALEX"}))
+
+	assert.ErrorIs(t, Validate(user{"ALEX"}), ErrInvalidValidatorSyntax)
+}
+
+type Money struct {
+	Amount   int64
+	Currency string `validate:"len:3"`
+}
+
+func TestRegisterTypeValidator(t *testing.T) {
+	v := New()
+	v.RegisterTypeValidator(reflect.TypeOf(Money{}), func(val reflect.Value) error {
+		if val.FieldByName("Amount").Int() < 0 {
+			return errors.New("amount can't be negative")
+		}
+		return nil
+	})
+
+	type order struct {
+		Total    Money
+		Discount *Money
+		Refund   *Money
+		Prices   []Money
+	}
+
+	assert.NoError(t, v.Validate(order{Total: Money{100, "EUR"}, Discount: &Money{5, "EUR"}}))
+
+	err := v.Validate(order{Total: Money{-1, "EURO"}, Discount: &Money{-5, "EUR"}})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	assert.Equal(t, "Total", e[0].FieldName)
+	assert.Equal(t, "validator.Money", e[0].Rule)
+	assert.Equal(t, "amount can't be negative", e[0].Err.Error())
+	assert.Equal(t, "Total.Currency", e[1].FieldName)
+	assert.Equal(t, "Discount", e[2].FieldName)
+
+	// Default has its own registry
+	assert.NoError(t, Validate(order{Total: Money{-1, "EUR"}}))
+}
+
+func TestRegisterContextValidator(t *testing.T) {
+	type ctxKey struct{}
+	var taken = map[string]bool{"alex": true}
+
+	v := New()
+	v.RegisterContextValidator("available", func(ctx context.Context, val reflect.Value) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if taken[val.String()] {
+			return errors.New("name is already taken by " + fmt.Sprint(ctx.Value(ctxKey{})))
+		}
+		return nil
+	})
+
+	type user struct {
+		Name  string `validate:"available;min:3"`
+		Email string `validate:"email"`
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "db")
+	assert.NoError(t, v.ValidateContext(ctx, user{"bob", "bob@example.com"}))
+
+	err := v.ValidateContext(ctx, user{"alex", "alex@example.com"})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 1)
+	assert.Equal(t, "available", e[0].Rule)
+	assert.Equal(t, "name is already taken by db", e[0].Err.Error())
+
+	// Validate passes a background context
+	err = v.Validate(user{"alex", "alex@example.com"})
+	assert.Equal(t, "name is already taken by <nil>", err.(ValidationErrors)[0].Err.Error())
+
+	// the walk stops on a cancelled context before the validator is called
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, v.ValidateContext(cancelled, user{"bob", "x"}), context.Canceled)
+
+	// a context cancelled during the walk is seen by the validator
+	running, cancel := context.WithCancel(ctx)
+	defer cancel()
+	v.RegisterValidator("cancel", func(val reflect.Value) error {
+		cancel()
+		return nil
+	})
+	err = v.ValidateContext(running, struct {
+		A string `validate:"cancel;available"`
+		B string `validate:"min:100"`
+	}{"bob", ""})
+	assert.ErrorIs(t, err, context.Canceled)
+}
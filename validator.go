@@ -1,18 +1,140 @@
 package validator
 
 import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
 	"github.com/pkg/errors"
+	"math"
+	"math/cmplx"
+	"net"
+	"net/mail"
+	"net/url"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 var ErrNotStruct = errors.New("wrong argument given, should be a struct")
 var ErrInvalidValidatorSyntax = errors.New("invalid validator syntax")
 var ErrValidateForUnexportedFields = errors.New("validation for unexported field is not allowed")
+var ErrNilPointer = errors.New("nil pointer given, should be a pointer to a struct")
+var ErrTooManyErrors = errors.New("too many errors")
+var ErrNotAddressable = errors.New("defaults can be set only through a pointer to a struct")
 
+// Validator validates structs with the constraints from their tags.
+// Options must be set before the first use, a Validator is safe for concurrent use afterwards.
+type Validator struct {
+	// TagKey is the struct tag key constraints are read from
+	TagKey string
+	// CountRunes makes min, max and len of strings count runes, otherwise they count bytes
+	CountRunes bool
+	// TimeLayout is the layout used to parse the bounds of after and before constraints
+	TimeLayout string
+	// IgnoreUnsupportedKinds turns off reporting of constraints on fields whose kind has no checker,
+	// e.g. min on a struct or a channel, and of constraints the checker of a kind ignores, e.g. email on an int
+	// or min on a bool. It's meant for a gradual adoption of the package.
+	IgnoreUnsupportedKinds bool
+	// PreserveSpaces keeps spaces around constraint names, values and elements of in and notin lists,
+	// by default they are trimmed, so "in: red, green" is the same as "in:red,green"
+	PreserveSpaces bool
+	// MaxErrors limits the number of collected errors, when it's exceeded the walk stops, even inside
+	// a slice or a map, and the last error is ErrTooManyErrors. There is no limit when it's 0.
+	MaxErrors int
+	// AllowUnexported makes tagged unexported fields silently skipped instead of failing with
+	// ErrValidateForUnexportedFields. Their tags are still ignored, values of unexported fields
+	// can't be read safely through reflection, so they are never validated.
+	AllowUnexported bool
+	// FieldNameTag is the struct tag key, e.g. "json", whose name is used for fields in errors instead of
+	// the Go name. The Go name is kept for fields without a name in that tag or named "-".
+	FieldNameTag string
+	// ExplicitNested makes struct fields, or pointers to structs, validated only when they are tagged
+	// with nested, e.g. `validate:"nested"`. By default every nested struct is validated.
+	// Fields of embedded structs are always validated as they are promoted. Structs behind interface fields
+	// are validated only when tagged with nested whatever the option.
+	ExplicitNested bool
+	// ConstraintSep separates constraints of a tag, KVSep separates a constraint name from its value
+	// and ListSep separates values of in, notin, oneof and url lists. They default to ";", ":" and ","
+	// and must not be empty.
+	ConstraintSep string
+	KVSep         string
+	ListSep       string
+
+	validatorsMu   sync.RWMutex
+	validators     map[string]ContextValidatorFunc
+	typeValidators map[reflect.Type]ValidatorFunc
+	messageFunc    MessageFunc
+
+	// cache holds []fieldConstraints of parsed struct tags by cacheKey
+	cache sync.Map
+}
+
+// cacheKey identifies parsed tags of a struct type, the layout is a part of it as after and before depend on it.
+// tagKeys are joined with spaces which can't appear in a tag key.
+type cacheKey struct {
+	typ        reflect.Type
+	tagKeys    string
+	timeLayout string
+}
+
+// fieldConstraints holds the parsed tag of a single struct field together with its syntax errors,
+// and what validation needs to know about the field, so that it doesn't read the field or its tags again.
+type fieldConstraints struct {
+	constraints Constraints
+	errs        ValidationErrors
+	field       reflect.StructField
+	// name is the name of the field in errors, see FieldNameTag
+	name string
+	// tagged is set when the field has any of the tags, a tagged unexported field can't be validated
+	tagged bool
+}
+
+// DefaultTagKey is the struct tag key used by Validate.
+const DefaultTagKey = "validate"
+
+// New returns a Validator with the default options and an empty validator registry.
+func New() *Validator {
+	return &Validator{
+		TagKey:         DefaultTagKey,
+		CountRunes:     true,
+		TimeLayout:     "2006-01-02",
+		ConstraintSep:  ";",
+		KVSep:          ":",
+		ListSep:        ",",
+		validators:     map[string]ContextValidatorFunc{},
+		typeValidators: map[reflect.Type]ValidatorFunc{},
+	}
+}
+
+// Default is the Validator used by the package-level functions.
+var Default = New()
+
+// ValidationError describes a single failed constraint.
+// FieldName and Rule are empty for errors not related to a particular field.
 type ValidationError struct {
-	Err error
+	Err       error
+	FieldName string
+	Rule      string
+}
+
+// Error returns the message of Err prefixed with the field name, e.g. "field: Name err: value is required".
+func (v ValidationError) Error() string {
+	if v.FieldName != "" {
+		return "field: " + v.FieldName + " err: " + v.Err.Error()
+	}
+	return v.Err.Error()
+}
+
+// Unwrap returns Err, so errors.Is and errors.As look through a single ValidationError.
+func (v ValidationError) Unwrap() error {
+	return v.Err
 }
 
 type ValidationErrors []ValidationError
@@ -20,183 +142,2540 @@ type ValidationErrors []ValidationError
 func (v ValidationErrors) Error() string {
 	var res []string
 	for _, validationError := range v {
-		res = append(res, validationError.Err.Error())
+		res = append(res, validationError.Error())
 	}
 	return strings.Join(res, ",")
 }
 
-func Validate(v any) error {
-	var validationErrors ValidationErrors
+// Unwrap returns the contained errors, so errors.As finds a ValidationError in them
+// and errors.Is and errors.As look through the Err of every one of them.
+func (v ValidationErrors) Unwrap() []error {
+	res := make([]error, 0, len(v))
+	for _, validationError := range v {
+		res = append(res, validationError)
+	}
+	return res
+}
+
+// GroupByField joins errors of every field into one error, errors not related
+// to a particular field are grouped under the empty name.
+func (v ValidationErrors) GroupByField() map[string]error {
+	errs := map[string][]error{}
+	for _, validationError := range v {
+		errs[validationError.FieldName] = append(errs[validationError.FieldName], validationError.Err)
+	}
+
+	res := make(map[string]error, len(errs))
+	for name, fieldErrs := range errs {
+		res[name] = stderrors.Join(fieldErrs...)
+	}
+	return res
+}
 
-	if reflect.TypeOf(v).Kind() == reflect.Struct {
-		s := reflect.TypeOf(v)
-		elem := reflect.ValueOf(&v).Elem().Elem()
+// MarshalJSON encodes errors as an array of {"field", "rule", "message"} objects,
+// field is null for errors not related to a particular field.
+func (v ValidationErrors) MarshalJSON() ([]byte, error) {
+	type jsonError struct {
+		Field   *string `json:"field"`
+		Rule    string  `json:"rule"`
+		Message string  `json:"message"`
+	}
 
-		for i := 0; i < s.NumField(); i++ {
-			if t := s.Field(i).Tag.Get("validate"); !s.Field(i).IsExported() && len(t) != 0 {
-				return ValidationErrors{ValidationError{ErrValidateForUnexportedFields}} // ErrValidateForUnexportedFields
-			} else {
-				var constraints Constraints
-				constraints, validationErrors = ParseConstraints(s.Field(i), validationErrors)
-				validationErrors = CheckConstraints(elem.Field(i), s.Field(i).Name, constraints, validationErrors)
-			}
+	res := make([]jsonError, 0, len(v))
+	for _, validationError := range v {
+		e := jsonError{Rule: validationError.Rule, Message: validationError.Err.Error()}
+		if validationError.FieldName != "" {
+			field := validationError.FieldName
+			e.Field = &field
 		}
-	} else {
-		return ErrNotStruct
+		res = append(res, e)
 	}
+	return json.Marshal(res)
+}
 
-	if len(validationErrors) == 0 {
-		return nil
+// ByField returns errors of the field with the given name.
+func (v ValidationErrors) ByField(name string) ValidationErrors {
+	var res ValidationErrors
+	for _, validationError := range v {
+		if validationError.FieldName == name {
+			res = append(res, validationError)
+		}
 	}
-	return validationErrors
+	return res
 }
 
-// validate:"max:2;min:3;len:3;in:2,3,4,"`
+// ToJoined converts ValidationErrors into an error made by errors.Join of every ValidationError,
+// for code which handles errors joined by the standard library. Other errors, including nil,
+// are returned as is.
+func ToJoined(err error) error {
+	validationErrors, ok := err.(ValidationErrors)
+	if !ok {
+		return err
+	}
 
-func ParseConstraints(f reflect.StructField, validationErrors ValidationErrors) (Constraints, ValidationErrors) {
-	constraints := NewConstraints()
+	errs := make([]error, 0, len(validationErrors))
+	for _, validationError := range validationErrors {
+		errs = append(errs, validationError)
+	}
+	return stderrors.Join(errs...)
+}
 
-	if s := f.Tag.Get("validate"); len(s) != 0 {
-		cons := strings.Split(s, ";")
+// Validate validates v with Default, see (*Validator).Validate.
+func Validate(v any) error {
+	return Default.Validate(v)
+}
 
-		for _, con := range cons {
-			s := strings.Split(con, ":")
-			switch s[0] {
-			case "max":
-				max, err := ParseInt(s[1])
-				if err != nil {
-					validationErrors = append(validationErrors, ValidationError{err})
-				} else {
-					constraints.max = max
-				}
-			case "min":
-				min, err := ParseInt(s[1])
-				if err != nil {
-					validationErrors = append(validationErrors, ValidationError{err})
-				} else {
-					constraints.min = min
-				}
-			case "len":
-				l, err := ParseInt(s[1])
-				if err != nil {
-					validationErrors = append(validationErrors, ValidationError{err})
-				} else if l < 0 {
-					validationErrors = append(validationErrors, ValidationError{errors.New("wrong length")})
-				} else {
-					constraints.len = l
-				}
-			case "in":
-				constraints.in = strings.Split(s[1], ",")
-			}
-		}
+// Validate validates the struct x, or the struct behind a pointer or an interface.
+// The result is either nil or a non-empty ValidationErrors, except for errors about x itself
+// which aren't ValidationErrors, use errors.Is to check for ErrNotStruct and ErrNilPointer.
+func (v *Validator) Validate(x any) error {
+	return v.ValidateWithTag(x, v.TagKey)
+}
+
+// MustValidate validates v with Default and panics on failure, see (*Validator).MustValidate.
+func MustValidate(v any) {
+	Default.MustValidate(v)
+}
+
+// MustValidate works like Validate, but panics with the returned error, e.g. ValidationErrors,
+// instead of returning it. It's meant for tests and for checks of static configuration on start.
+func (v *Validator) MustValidate(x any) {
+	if err := v.Validate(x); err != nil {
+		panic(err)
 	}
+}
 
-	return constraints, validationErrors
+// IsValid reports whether v passes Validate of Default.
+func IsValid(v any) bool {
+	return Default.IsValid(v)
 }
 
-func CheckConstraints(val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) ValidationErrors {
-	if val.Kind() == reflect.String {
-		return checkStringConstraints(val, fieldName, constraints, validationErrors)
+// IsValid reports whether x passes Validate, any error including ErrNotStruct makes x invalid.
+func (v *Validator) IsValid(x any) bool {
+	return v.Validate(x) == nil
+}
+
+// ValidateWithTag works like Validate, but reads constraints from the tagKey struct tag.
+func ValidateWithTag(v any, tagKey string) error {
+	return Default.ValidateWithTag(v, tagKey)
+}
+
+// ValidateWithTag works like Validate, but reads constraints from the tagKey struct tag.
+func (v *Validator) ValidateWithTag(x any, tagKey string) error {
+	return v.ValidateWithTags(x, tagKey)
+}
+
+// ValidateWithTags works like Validate, but reads constraints from several struct tags with Default,
+// see (*Validator).ValidateWithTags.
+func ValidateWithTags(v any, tagKeys ...string) error {
+	return Default.ValidateWithTags(v, tagKeys...)
+}
+
+// ValidateWithTags works like Validate, but merges constraints of a field from every tag of tagKeys.
+// The tags are parsed in the given order as a single one, so a constraint from a later key
+// replaces the same constraint from an earlier one, e.g. max of `validate:"max:5" valid:"max:3"` is 3.
+func (v *Validator) ValidateWithTags(x any, tagKeys ...string) error {
+	return validate(reflect.ValueOf(x), v.newWalker(tagKeys...))
+}
+
+// ValidateContext validates v with Default passing ctx to custom validators, see (*Validator).ValidateContext.
+func ValidateContext(ctx context.Context, v any) error {
+	return Default.ValidateContext(ctx, v)
+}
+
+// ValidateContext works like Validate, but passes ctx to validators registered with RegisterContextValidator,
+// built-in constraints ignore it. When ctx is done the walk stops and ctx.Err() is returned.
+func (v *Validator) ValidateContext(ctx context.Context, x any) error {
+	w := v.newWalker(v.TagKey)
+	w.ctx = ctx
+	return validate(reflect.ValueOf(x), w)
+}
+
+// ValidateFirst works like Validate, but stops on the first failed field
+// and returns ValidationErrors with the single error.
+func ValidateFirst(v any) error {
+	return Default.ValidateFirst(v)
+}
+
+// ValidateFirst works like Validate, but stops on the first failed field
+// and returns ValidationErrors with the single error.
+func (v *Validator) ValidateFirst(x any) error {
+	w := v.newWalker(v.TagKey)
+	w.failFast = true
+	return validate(reflect.ValueOf(x), w)
+}
+
+// ValidateLenient works like Validate, but doesn't abort on tagged unexported fields,
+// such fields are skipped and reported as ErrValidateForUnexportedFields among the other errors.
+func ValidateLenient(v any) error {
+	return Default.ValidateLenient(v)
+}
+
+// ValidateLenient works like Validate, but doesn't abort on tagged unexported fields,
+// such fields are skipped and reported as ErrValidateForUnexportedFields among the other errors.
+func (v *Validator) ValidateLenient(x any) error {
+	w := v.newWalker(v.TagKey)
+	w.lenient = true
+	return validate(reflect.ValueOf(x), w)
+}
+
+// ValidateValue works like Validate, but takes an existing reflect.Value,
+// pointers and interfaces are unwrapped the same way.
+func ValidateValue(rv reflect.Value) error {
+	return Default.ValidateValue(rv)
+}
+
+// ValidateValue works like Validate, but takes an existing reflect.Value,
+// pointers and interfaces are unwrapped the same way.
+func (v *Validator) ValidateValue(rv reflect.Value) error {
+	return validate(rv, v.newWalker(v.TagKey))
+}
+
+func validate(rv reflect.Value, w *walker) error {
+	elem, err := w.unwrap(rv)
+	if err != nil {
+		return err
 	}
 
-	if val.Kind() == reflect.Int {
-		return checkIntConstraints(val, fieldName, constraints, validationErrors)
+	if elem.Kind() != reflect.Struct {
+		return notStruct(kindName(elem))
 	}
 
-	if val.Kind() == reflect.Slice {
-		return checkSliceConstraints(val, fieldName, constraints, validationErrors)
+	validationErrors, err := w.validateStruct(elem, "", nil)
+	if err != nil {
+		return err
 	}
 
+	if len(validationErrors) == 0 {
+		return nil
+	}
+	if w.failFast {
+		return validationErrors[:1]
+	}
 	return validationErrors
 }
 
-func checkStringConstraints(val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) ValidationErrors {
-	if constraints.max != -1 && len(val.String()) > constraints.max {
-		validationErrors = append(validationErrors, ValidationError{errors.New("field: " + fieldName + " err: length can't be more than max")})
+// ValidateField checks value against the constraints of tag with Default, see (*Validator).ValidateField.
+func ValidateField(value any, tag string) error {
+	return Default.ValidateField(value, tag)
+}
+
+// ValidateField checks value against tag, a constraint string like "min:2;max:10", as if value was
+// a field with that tag. Errors have no field name. Tag syntax errors are returned as ValidationErrors
+// matching ErrInvalidValidatorSyntax before the value is checked. nil fails only required.
+// Fields of a struct value aren't validated, cross-field constraints like eqfield aren't applicable.
+func (v *Validator) ValidateField(value any, tag string) error {
+	val := reflect.ValueOf(value)
+	if value == nil {
+		val = reflect.ValueOf(&value).Elem()
 	}
-	if constraints.min != -1 && len(val.String()) < constraints.min {
-		validationErrors = append(validationErrors, ValidationError{errors.New("field: " + fieldName + " err: length can't be less than min")})
+
+	constraints, validationErrors := v.parseTag(reflect.StructField{Type: val.Type()}, tag, nil)
+	if constraints.fieldRefs != nil {
+		validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, constraints.fieldRefs[0].rule+" isn't applicable outside a struct"), Rule: constraints.fieldRefs[0].rule})
 	}
-	if constraints.len != -1 && len(val.String()) != constraints.len {
-		validationErrors = append(validationErrors, ValidationError{errors.New("field: " + fieldName + " err: length must be equal to len")})
+	if len(validationErrors) != 0 {
+		return validationErrors
 	}
 
-	if constraints.in != nil {
-		var find bool
-		for _, s := range constraints.in {
-			if val.String() == s {
-				find = true
-				break
-			}
+	// like a nil pointer field, nil has no value to check unless it's required
+	if value == nil && !constraints.required {
+		return nil
+	}
+
+	validationErrors = CheckConstraints(val, "", constraints, nil)
+	if len(validationErrors) == 0 {
+		return nil
+	}
+	return validationErrors
+}
+
+// ValidateDetailed validates v and returns failure messages grouped by field name.
+// The error is reserved for problems which aren't caused by field values,
+// like ErrNotStruct or an invalid tag syntax.
+func ValidateDetailed(v any) (map[string][]string, error) {
+	return Default.ValidateDetailed(v)
+}
+
+// ValidateDetailed validates x and returns failure messages grouped by field name, see ValidateDetailed.
+func (v *Validator) ValidateDetailed(x any) (map[string][]string, error) {
+	res := map[string][]string{}
+
+	err := v.Validate(x)
+	if err == nil {
+		return res, nil
+	}
+
+	var validationErrors ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil, err
+	}
+
+	var structural ValidationErrors
+	for _, validationError := range validationErrors {
+		if validationError.FieldName == "" || errors.Is(validationError.Err, ErrInvalidValidatorSyntax) {
+			structural = append(structural, validationError)
+			continue
 		}
-		if !find {
-			validationErrors = append(validationErrors, ValidationError{errors.New("field: " + fieldName + " err: value is not contained in the 'in'")})
+		res[validationError.FieldName] = append(res[validationError.FieldName], validationError.Err.Error())
+	}
+
+	if len(structural) != 0 {
+		return res, structural
+	}
+	return res, nil
+}
+
+// ValidateMany validates every element of a slice or an array of structs (or pointers to structs),
+// field names are prefixed with the element index, e.g. "[3].Total".
+func ValidateMany(v any) error {
+	return Default.ValidateMany(v)
+}
+
+// ValidateMany validates every element of a slice or an array of structs, see ValidateMany.
+func (v *Validator) ValidateMany(x any) error {
+	elems, err := v.structElems(x)
+	if err != nil {
+		return err
+	}
+
+	var validationErrors ValidationErrors
+	for i := 0; i < elems.Len(); i++ {
+		prefix := indexPath("", i)
+		w := v.newWalker(v.TagKey)
+
+		elem, err := w.unwrap(elems.Index(i))
+		if err == nil && elem.Kind() != reflect.Struct {
+			err = notStruct(kindName(elem))
+		}
+		if err != nil {
+			validationErrors = append(validationErrors, ValidationError{Err: err, FieldName: prefix})
+		} else if validationErrors, err = w.validateStruct(elem, prefix+".", validationErrors); err != nil {
+			return err
+		}
+
+		var stop bool
+		if validationErrors, stop = w.stop(validationErrors); stop {
+			break
 		}
 	}
 
+	if len(validationErrors) == 0 {
+		return nil
+	}
 	return validationErrors
 }
 
-func checkIntConstraints(val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) ValidationErrors {
-	if constraints.max != -1 && val.Int() > int64(constraints.max) {
-		validationErrors = append(validationErrors, ValidationError{errors.New("field: " + fieldName + " err: value can't be more than max")})
+// ValidateEach validates every element of a slice or an array of structs with Default, see (*Validator).ValidateEach.
+func ValidateEach(v any, fn func(index int, err error) bool) error {
+	return Default.ValidateEach(v, fn)
+}
+
+// ValidateEach validates every element of a slice or an array of structs (or pointers to structs)
+// and passes the result of each one to fn, the walk stops when fn returns false.
+// Unlike ValidateMany errors aren't accumulated, field names are relative to the element.
+// The returned error is about x itself, e.g. ErrNotStruct when it isn't a slice of structs.
+func (v *Validator) ValidateEach(x any, fn func(index int, err error) bool) error {
+	elems, err := v.structElems(x)
+	if err != nil {
+		return err
 	}
-	if constraints.min != -1 && val.Int() < int64(constraints.min) {
-		validationErrors = append(validationErrors, ValidationError{errors.New("field: " + fieldName + " err: value can't be less than min")})
+
+	for i := 0; i < elems.Len(); i++ {
+		if !fn(i, validate(elems.Index(i), v.newWalker(v.TagKey))) {
+			break
+		}
 	}
-	if constraints.len != -1 {
-		validationErrors = append(validationErrors, ValidationError{ErrInvalidValidatorSyntax})
+	return nil
+}
+
+// structElems unwraps x and checks it's a slice or an array of structs, pointers to structs or interfaces.
+func (v *Validator) structElems(x any) (reflect.Value, error) {
+	elems, err := v.newWalker(v.TagKey).unwrap(reflect.ValueOf(x))
+	if err != nil {
+		return elems, err
 	}
 
-	if constraints.in != nil {
-		var find bool
-		for _, s := range constraints.in {
+	if elems.Kind() != reflect.Slice && elems.Kind() != reflect.Array {
+		return elems, notStruct(kindName(elems))
+	}
+
+	t := elems.Type().Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct && t.Kind() != reflect.Interface {
+		return elems, notStruct(elems.Kind().String() + " of " + t.Kind().String())
+	}
+	return elems, nil
+}
+
+// notStruct wraps ErrNotStruct with a description of the given value,
+// e.g. "wrong argument given, should be a struct, got map".
+func notStruct(got string) error {
+	return fmt.Errorf("%w, got %s", ErrNotStruct, got)
+}
+
+// kindName describes the kind of val for notStruct, an invalid Value is what a nil any is reflected to.
+func kindName(val reflect.Value) string {
+	if !val.IsValid() {
+		return "nil"
+	}
+	return val.Kind().String()
+}
+
+// visit identifies a struct reached through a pointer, it's used to stop on self-referential values.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// walker holds the state of a single validation run.
+type walker struct {
+	v *Validator
+	// ctx is passed to custom validators, the walk stops once it's done
+	ctx     context.Context
+	visited map[visit]bool
+	tagKeys []string
+	// compiled holds tags parsed by Compile, types missing in it are looked up in the cache
+	compiled map[reflect.Type][]fieldConstraints
+	// failFast stops the walk as soon as a field fails
+	failFast bool
+	// lenient skips tagged unexported fields instead of aborting the walk
+	lenient bool
+}
+
+func (v *Validator) newWalker(tagKeys ...string) *walker {
+	return &walker{v: v, ctx: context.Background(), visited: map[visit]bool{}, tagKeys: tagKeys}
+}
+
+// stop reports whether the walk must stop, either on the first failure in the fail fast mode
+// or when there are more than MaxErrors errors. The errors beyond MaxErrors are replaced with ErrTooManyErrors.
+func (w *walker) stop(validationErrors ValidationErrors) (ValidationErrors, bool) {
+	if w.failFast && len(validationErrors) > 0 {
+		return validationErrors, true
+	}
+
+	max := w.v.MaxErrors
+	if max <= 0 || len(validationErrors) <= max {
+		return validationErrors, false
+	}
+	if validationErrors[max].Err != ErrTooManyErrors {
+		validationErrors = append(validationErrors[:max:max], ValidationError{Err: ErrTooManyErrors})
+	}
+	return validationErrors[:max+1], true
+}
+
+// limit returns the number of errors checkConstraints may stop at, a single one in the fail fast mode,
+// otherwise one more than MaxErrors so that stop sees they are exceeded, see checkConstraints.
+func (w *walker) limit() int {
+	if w.failFast {
+		return 1
+	}
+	if w.v.MaxErrors <= 0 {
+		return 0
+	}
+	return w.v.MaxErrors + 1
+}
+
+// reached reports whether there are limit errors, see checkConstraints.
+func reached(limit int, validationErrors ValidationErrors) bool {
+	return limit > 0 && len(validationErrors) >= limit
+}
+
+// unwrap dereferences pointers and interfaces until it reaches a concrete value, so a struct is reached
+// the same way whether it's passed by value, by pointer or boxed in an interface.
+// Pointers on the way are marked as visited.
+func (w *walker) unwrap(val reflect.Value) (reflect.Value, error) {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() && val.Kind() == reflect.Ptr {
+			return val, ErrNilPointer
+		} else if val.IsNil() {
+			return val, notStruct("nil interface")
+		}
+
+		if val.Kind() == reflect.Ptr {
+			w.visited[visit{val.Pointer(), val.Type()}] = true
+		}
+		val = val.Elem()
+	}
+
+	return val, nil
+}
+
+func (w *walker) validateStruct(elem reflect.Value, prefix string, validationErrors ValidationErrors) (ValidationErrors, error) {
+	fields := w.structConstraints(elem.Type())
+
+	for i := range fields {
+		if err := w.ctx.Err(); err != nil {
+			return nil, err
+		}
+		f := &fields[i].field
+		if !f.IsExported() && fields[i].tagged {
+			if w.v.AllowUnexported {
+				continue
+			}
+			if w.lenient {
+				validationErrors = append(validationErrors, ValidationError{Err: ErrValidateForUnexportedFields, FieldName: prefix + f.Name})
+				continue
+			}
+			return nil, ValidationErrors{ValidationError{Err: ErrValidateForUnexportedFields}} // ErrValidateForUnexportedFields
+		}
+
+		name := fields[i].name
+		constraints := fields[i].constraints
+		for _, parseErr := range fields[i].errs {
+			parseErr.FieldName = prefix + name
+			validationErrors = append(validationErrors, parseErr)
+		}
+		if constraints.def != nil {
+			validationErrors = fillDefault(elem.Field(i), prefix+name, *constraints.def, validationErrors)
+		}
+		validationErrors = checkConstraints(w.ctx, elem.Field(i), prefix+name, constraints, w.limit(), validationErrors)
+		validationErrors = checkFieldRefs(elem, elem.Field(i), prefix+name, constraints, validationErrors)
+		if validationErrors, stop := w.stop(validationErrors); stop {
+			return validationErrors, nil
+		}
+
+		// fields of embedded structs are promoted, even when the embedded type itself is unexported
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		// nested structs and type validators are skipped for empty optional fields as well
+		if constraints.omitempty && elem.Field(i).IsZero() {
+			continue
+		}
+
+		if f.IsExported() {
+			validationErrors = w.v.checkTypeValidators(elem.Field(i), prefix+name, validationErrors)
+		}
+
+		fieldPrefix := prefix + name + "."
+		if f.Anonymous {
+			fieldPrefix = prefix
+		}
+
+		if f.Anonymous && elem.Field(i).Kind() == reflect.Ptr && elem.Field(i).IsNil() {
+			validationErrors = w.checkNilEmbedded(f.Type.Elem(), f.Name, fieldPrefix, validationErrors)
+		}
 
-			num, err := strconv.Atoi(s)
+		nested := elem.Field(i)
+		// a value behind an interface is validated only when tagged with nested, its type is a part of the path
+		if nested.Kind() == reflect.Interface && constraints.nested && !nested.IsNil() {
+			fieldPrefix = prefix + name + ".(" + nested.Elem().Type().String() + ")."
+			nested = nested.Elem()
+		}
+
+		var err error
+		if !w.v.ExplicitNested || constraints.nested || f.Anonymous {
+			validationErrors, err = w.validateNested(nested, fieldPrefix, validationErrors)
 			if err != nil {
-				validationErrors = append(validationErrors, ValidationError{ErrInvalidValidatorSyntax})
+				return nil, err
 			}
+		}
 
-			if val.Int() == int64(num) {
-				find = true
-				break
+		if constraints.dive {
+			validationErrors, err = w.dive(elem.Field(i), prefix+name, constraints, validationErrors)
+			if err != nil {
+				return nil, err
 			}
 		}
-		if !find {
-			validationErrors = append(validationErrors, ValidationError{errors.New("field: " + fieldName + " err: value is not contained in the 'in'")})
+
+		if validationErrors, stop := w.stop(validationErrors); stop {
+			return validationErrors, nil
 		}
 	}
+
+	return validationErrors, nil
+}
+
+// fieldName returns the name of the field f in errors, see FieldNameTag.
+func (v *Validator) fieldName(f reflect.StructField) string {
+	if v.FieldNameTag == "" {
+		return f.Name
+	}
+	name, _, _ := strings.Cut(f.Tag.Get(v.FieldNameTag), ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
+
+// fillDefault sets val to def when val is zero, val must be settable even when it isn't zero.
+func fillDefault(val reflect.Value, fieldName, def string, validationErrors ValidationErrors) ValidationErrors {
+	if !val.CanSet() {
+		return append(validationErrors, ValidationError{Err: ErrNotAddressable, FieldName: fieldName, Rule: "default"})
+	}
+	if val.IsZero() {
+		// def was already parsed into a value of this type by ParseConstraints
+		_ = setDefault(val, def)
+	}
 	return validationErrors
 }
 
-func checkSliceConstraints(val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) ValidationErrors {
-	for i := 0; i < val.Len(); i++ {
-		if val.Index(i).Kind() == reflect.Int {
-			validationErrors = checkIntConstraints(val.Index(i), fieldName+" "+strconv.Itoa(i)+"th element", constraints, validationErrors)
-		} else {
-			validationErrors = checkStringConstraints(val.Index(i), fieldName+" "+strconv.Itoa(i)+"th element", constraints, validationErrors)
+// setDefault parses s according to the kind of val and stores the result in val,
+// a nil pointer is set to a new value.
+func setDefault(val reflect.Value, s string) error {
+	switch val.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(val.Type().Elem())
+		if err := setDefault(elem.Elem(), s); err != nil {
+			return err
+		}
+		val.Set(elem)
+	case reflect.String:
+		val.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
 		}
+		val.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetFloat(f)
+	default:
+		return errors.New("default isn't applicable to kind " + val.Kind().String())
+	}
+	return nil
+}
+
+// checkNilEmbedded reports required fields promoted from the struct t embedded by the nil pointer embedName,
+// the other promoted fields are skipped as they have no values.
+func (w *walker) checkNilEmbedded(t reflect.Type, embedName, prefix string, validationErrors ValidationErrors) ValidationErrors {
+	if t.Kind() != reflect.Struct {
+		return validationErrors
 	}
 
+	fields := w.structConstraints(t)
+	for i := range fields {
+		f := fields[i].field
+		if fields[i].constraints.required && f.IsExported() {
+			validationErrors = append(validationErrors, fields[i].constraints.failure(prefix+fields[i].name, "required", "value is required, but embedded "+embedName+" is nil"))
+		}
+		// structs embedded by value are absent together with t, pointers can't be followed without a value
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			validationErrors = w.checkNilEmbedded(f.Type, embedName, prefix, validationErrors)
+		}
+	}
 	return validationErrors
 }
 
-func ParseInt(s string) (int, error) {
-	val, err := strconv.Atoi(s)
-	if err != nil {
-		return 0, ErrInvalidValidatorSyntax
+// indexPath returns the path of the i-th element of the slice or the array fieldName, e.g. "Items[2]".
+func indexPath(fieldName string, i int) string {
+	return fieldName + "[" + strconv.Itoa(i) + "]"
+}
+
+// mapValuePath returns the path of the value of the map fieldName under the key k, e.g. "Prices[eur]".
+func mapValuePath(fieldName string, k reflect.Value) string {
+	return fieldName + "[" + fmt.Sprint(k) + "]"
+}
+
+// mapKeyPath returns the path of the key k of the map fieldName, e.g. "Prices{eur}",
+// braces tell it from the path of the value whatever the key is.
+func mapKeyPath(fieldName string, k reflect.Value) string {
+	return fieldName + "{" + fmt.Sprint(k) + "}"
+}
+
+// invalidValue reports a constraint value which can't be parsed, e.g. "invalid max value 'abc'".
+func invalidValue(fieldName, key, value string) ValidationError {
+	return ValidationError{Err: errors.Wrapf(ErrInvalidValidatorSyntax, "invalid %s value '%s'", key, value), FieldName: fieldName, Rule: key}
+}
+
+// validateNested validates the struct (or the struct behind a pointer) val, values of other kinds are skipped.
+func (w *walker) validateNested(val reflect.Value, prefix string, validationErrors ValidationErrors) (ValidationErrors, error) {
+	var v visit
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return validationErrors, nil
+		}
+		v = visit{val.Pointer(), val.Type()}
+		if w.visited[v] {
+			return validationErrors, nil
+		}
+		val = val.Elem()
 	}
 
-	return val, nil
+	if val.Kind() != reflect.Struct || isTime(val.Type()) {
+		return validationErrors, nil
+	}
+
+	if v.ptr != 0 {
+		w.visited[v] = true
+		defer delete(w.visited, v)
+	}
+	return w.validateStruct(val, prefix, validationErrors)
+}
+
+// dive validates every struct element of the slice or array val, or of the one behind a pointer,
+// e.g. "Items[2].Price", elements are checked against the constraints following dive first.
+// Elements of other kinds are checked by CheckConstraints with the constraints following dive,
+// so dive on them is valid only together with such constraints.
+func (w *walker) dive(val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) (ValidationErrors, error) {
+	// a nil pointer to a slice or an array has no elements
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return validationErrors, nil
+		}
+		val = val.Elem()
+	}
+	// values of a map are checked by CheckConstraints with the constraints following dive
+	if val.Kind() == reflect.Map && constraints.elem != nil {
+		return validationErrors, nil
+	}
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "dive is applicable only to slices and to maps with value constraints"), FieldName: fieldName, Rule: "dive"}), nil
+	}
+	if !hasStructElems(val.Type()) {
+		if constraints.elem == nil {
+			return append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "dive without element constraints is applicable only to slices of structs"), FieldName: fieldName, Rule: "dive"}), nil
+		}
+		return validationErrors, nil
+	}
+
+	for i := 0; i < val.Len(); i++ {
+		// a nil element is skipped unless required follows dive
+		if constraints.elem != nil {
+			validationErrors = checkConstraints(w.ctx, val.Index(i), indexPath(fieldName, i), *constraints.elem, w.limit(), validationErrors)
+		}
+
+		var err error
+		validationErrors, err = w.validateNested(val.Index(i), indexPath(fieldName, i)+".", validationErrors)
+		if err != nil {
+			return nil, err
+		}
+		if validationErrors, stop := w.stop(validationErrors); stop {
+			return validationErrors, nil
+		}
+	}
+
+	return validationErrors, nil
+}
+
+// structConstraints returns parsed tags of every field of the struct type s.
+func (w *walker) structConstraints(s reflect.Type) []fieldConstraints {
+	if fields, ok := w.compiled[s]; ok {
+		return fields
+	}
+	return w.v.structConstraints(s, w.tagKeys)
+}
+
+// structConstraints returns parsed tags of every field of the struct type s, tags are parsed once per type.
+func (v *Validator) structConstraints(s reflect.Type, tagKeys []string) []fieldConstraints {
+	key := cacheKey{s, strings.Join(tagKeys, " "), v.TimeLayout}
+	if fields, ok := v.cache.Load(key); ok {
+		return fields.([]fieldConstraints)
+	}
+
+	fields := make([]fieldConstraints, s.NumField())
+	for i := range fields {
+		f := s.Field(i)
+		tag := v.mergedTag(f, tagKeys)
+		fields[i].constraints, fields[i].errs = v.parseTag(f, tag, nil)
+		fields[i].errs = fields[i].constraints.resolveFieldRefs(s, f, fields[i].errs)
+		fields[i].field, fields[i].name, fields[i].tagged = f, v.fieldName(f), tag != ""
+	}
+	v.cache.Store(key, fields)
+	return fields
+}
+
+// fieldRef is a sibling field referenced by a cross-field constraint: eqfield, gtfield or ltfield.
+type fieldRef struct {
+	rule string
+	name string
+	// fieldName is the name of the referenced field in errors, see FieldNameTag
+	fieldName string
+	index     []int
+}
+
+// resolveFieldRefs looks up fields referenced by cross-field constraints of the field f in the struct s,
+// constraints referring to a missing or unexported field or to a field of another type are dropped and reported.
+func (c *Constraints) resolveFieldRefs(s reflect.Type, f reflect.StructField, validationErrors ValidationErrors) ValidationErrors {
+	if c.elem != nil && c.elem.fieldRefs != nil {
+		validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, c.elem.fieldRefs[0].rule+" can't follow dive"), FieldName: f.Name, Rule: c.elem.fieldRefs[0].rule})
+	}
+
+	var refs []fieldRef
+	for _, ref := range c.fieldRefs {
+		other, ok := s.FieldByName(ref.name)
+		switch {
+		case !ok:
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, ref.rule+" refers to unknown field '"+ref.name+"'"), FieldName: f.Name, Rule: ref.rule})
+		case !other.IsExported():
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, ref.rule+" refers to unexported field '"+ref.name+"'"), FieldName: f.Name, Rule: ref.rule})
+		case other.Type != f.Type:
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, ref.rule+" refers to field '"+ref.name+"' of another type"), FieldName: f.Name, Rule: ref.rule})
+		case ref.rule == "eqfield" && !f.Type.Comparable(), ref.rule != "eqfield" && !isOrdered(f.Type):
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, ref.rule+" isn't applicable to "+f.Type.String()), FieldName: f.Name, Rule: ref.rule})
+		default:
+			ref.index = other.Index
+			ref.fieldName = c.validator().fieldName(other)
+			refs = append(refs, ref)
+		}
+	}
+	c.fieldRefs = refs
+	return validationErrors
+}
+
+// isOrdered reports whether values of t can be compared by gtfield and ltfield.
+func isOrdered(t reflect.Type) bool {
+	return isInt(t.Kind()) || isUint(t.Kind()) || t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64 || isTime(t)
+}
+
+// compareOrdered returns -1, 0 or 1 when a is less than, equal to or greater than b, both are of an ordered type.
+func compareOrdered(a, b reflect.Value) int {
+	var less, greater bool
+	switch {
+	case isInt(a.Kind()):
+		less, greater = a.Int() < b.Int(), a.Int() > b.Int()
+	case isUint(a.Kind()):
+		less, greater = a.Uint() < b.Uint(), a.Uint() > b.Uint()
+	case a.Kind() == reflect.Float32 || a.Kind() == reflect.Float64:
+		less, greater = a.Float() < b.Float(), a.Float() > b.Float()
+	default:
+		ta := a.Convert(timeType).Interface().(time.Time)
+		tb := b.Convert(timeType).Interface().(time.Time)
+		less, greater = ta.Before(tb), ta.After(tb)
+	}
+
+	if less {
+		return -1
+	}
+	if greater {
+		return 1
+	}
+	return 0
+}
+
+// checkFieldRefs compares val, a field of the struct parent, with the fields referenced by its constraints,
+// fields promoted through a nil embedded pointer are skipped.
+func checkFieldRefs(parent, val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) ValidationErrors {
+	if constraints.omitempty && val.IsZero() {
+		return validationErrors
+	}
+
+	for _, ref := range constraints.fieldRefs {
+		other, err := parent.FieldByIndexErr(ref.index)
+		if err != nil {
+			continue
+		}
+
+		switch {
+		// values behind interfaces, or in struct fields and array elements of interface types, may not be comparable
+		case ref.rule == "eqfield" && (!isComparable(val) || !isComparable(other)):
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "eqfield is applicable only to comparable values"), FieldName: fieldName, Rule: ref.rule})
+		case ref.rule == "eqfield" && !val.Equal(other):
+			validationErrors = append(validationErrors, constraints.failure(fieldName, ref.rule, "value must be equal to "+ref.fieldName))
+		case ref.rule == "gtfield" && compareOrdered(val, other) <= 0:
+			validationErrors = append(validationErrors, constraints.failure(fieldName, ref.rule, "value must be greater than "+ref.fieldName))
+		case ref.rule == "ltfield" && compareOrdered(val, other) >= 0:
+			validationErrors = append(validationErrors, constraints.failure(fieldName, ref.rule, "value must be less than "+ref.fieldName))
+		}
+	}
+	return validationErrors
+}
+
+// validate:"max:2;min:3;len:3;in:2,3,4,"`
+
+func ParseConstraints(f reflect.StructField, tagKey string, validationErrors ValidationErrors) (Constraints, ValidationErrors) {
+	return Default.ParseConstraints(f, tagKey, validationErrors)
+}
+
+// ParseConstraints parses the tagKey tag of f with the validators and the time layout of v.
+func (v *Validator) ParseConstraints(f reflect.StructField, tagKey string, validationErrors ValidationErrors) (Constraints, ValidationErrors) {
+	return v.parseTag(f, f.Tag.Get(tagKey), validationErrors)
+}
+
+// mergedTag joins values of the tagKeys tags of f into a single tag value.
+func (v *Validator) mergedTag(f reflect.StructField, tagKeys []string) string {
+	var tags []string
+	for _, tagKey := range tagKeys {
+		if t := f.Tag.Get(tagKey); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return strings.Join(tags, v.ConstraintSep)
+}
+
+// parseTag parses the tag value s of the field f.
+func (v *Validator) parseTag(f reflect.StructField, s string, validationErrors ValidationErrors) (Constraints, ValidationErrors) {
+	constraints := NewConstraints()
+	constraints.v = v
+
+	if len(s) != 0 {
+		cons := strings.Split(s, v.ConstraintSep)
+
+		var inKeys bool
+		for _, con := range cons {
+			// constraints between keys and endkeys are applied to keys of a map as if they were prefixed with "key="
+			switch strings.TrimSpace(con) {
+			case "keys":
+				inKeys = true
+				continue
+			case "endkeys":
+				if !inKeys {
+					validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "endkeys without keys"), FieldName: f.Name, Rule: "endkeys"})
+				}
+				inKeys = false
+				continue
+			}
+			if inKeys {
+				validationErrors = parseConstraint("key="+con, f.Name, &constraints, validationErrors)
+				continue
+			}
+
+			target := &constraints
+			// constraints following dive are applied to every element, except the ones about the slice itself
+			if constraints.dive && !isSliceLevel(con, v.KVSep) {
+				if constraints.elem == nil {
+					elem := NewConstraints()
+					elem.v = v
+					constraints.elem = &elem
+				}
+				target = constraints.elem
+			}
+			validationErrors = parseConstraint(con, f.Name, target, validationErrors)
+		}
+		if inKeys {
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "keys without endkeys"), FieldName: f.Name, Rule: "keys"})
+		}
+
+		if constraints.def != nil {
+			if err := setDefault(reflect.New(f.Type).Elem(), *constraints.def); err != nil {
+				validationErrors = append(validationErrors, invalidValue(f.Name, "default", *constraints.def))
+				constraints.def = nil
+			}
+		}
+
+		// len contradicts min and max where they all bound a length, len of integers is the number of digits
+		lengths := []bool{measuresLength(valueType(f.Type)) || isMap(f.Type), measuresLength(elemType(f.Type))}
+		for i, c := range []*Constraints{&constraints, constraints.elem} {
+			if c != nil && lengths[i] && c.len != -1 && (c.min != nil || c.max != nil) {
+				validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "len can't be combined with min or max"), FieldName: f.Name, Rule: "len"})
+			}
+			// no value satisfies inverted bounds, they are a typo rather than a constraint and are dropped
+			if c != nil && c.min != nil && c.max != nil && *c.min > *c.max {
+				validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "min can't be more than max"), FieldName: f.Name, Rule: "min"})
+				c.min, c.max = nil, nil
+				c.intBounds.min, c.intBounds.max, c.uintBounds.min, c.uintBounds.max = nil, nil, nil, nil
+			}
+		}
+		if constraints.minLen != -1 && constraints.maxLen != -1 && constraints.minLen > constraints.maxLen {
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "minlen can't be more than maxlen"), FieldName: f.Name, Rule: "minlen"})
+			constraints.minLen, constraints.maxLen = -1, -1
+		}
+
+		validationErrors = constraints.parseInLists(valueType(f.Type), f.Name, validationErrors)
+		validationErrors = constraints.dropInapplicable(valueType(f.Type), isMap(f.Type), f.Name, validationErrors)
+		if constraints.elem != nil {
+			validationErrors = constraints.elem.parseInLists(elemType(f.Type), f.Name, validationErrors)
+			validationErrors = constraints.elem.dropInapplicable(elemType(f.Type), false, f.Name, validationErrors)
+		}
+
+		if constraints.unique {
+			t := f.Type
+			for t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+				validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "unique is applicable only to slices"), FieldName: f.Name, Rule: "unique"})
+			}
+		}
+		if constraints.keys != nil && !isMap(f.Type) {
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "key constraints are applicable only to maps"), FieldName: f.Name, Rule: "keys"})
+			constraints.keys = nil
+		} else if constraints.keys != nil {
+			validationErrors = constraints.keys.dropInapplicable(valueType(mapKey(f.Type)), false, f.Name, validationErrors)
+		}
+
+		if constraints.dive {
+			// only constraints on the slice itself may precede dive
+			before := constraints
+			before.dive, before.required, before.custom, before.v, before.elem = false, false, nil, nil, nil
+			before.omitempty, before.keys = false, nil
+			if isMap(f.Type) {
+				// len, min and max of a map bound the number of entries
+				before.len, before.min, before.max = -1, nil, nil
+				before.intBounds, before.uintBounds = exactBounds[int64]{}, exactBounds[uint64]{}
+			}
+			before.minLen, before.maxLen, before.exactLen, before.unique = -1, -1, -1, false
+			if !reflect.DeepEqual(before, NewConstraints()) {
+				validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "element constraints must follow dive"), FieldName: f.Name, Rule: "dive"})
+			}
+
+			// struct elements are validated by their own tags
+			if constraints.elem != nil && constraints.elem.hasValueConstraints() && hasStructElems(f.Type) {
+				validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "dive can't be combined with element constraints"), FieldName: f.Name, Rule: "dive"})
+			}
+		}
+	}
+
+	return constraints, validationErrors
+}
+
+// valueType returns the type value constraints of a field of type t are applied to,
+// they reach elements of slices, arrays and maps through pointers. A []byte is checked as a whole.
+func valueType(t reflect.Type) reflect.Type {
+	for {
+		switch {
+		case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+			return t
+		case t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+// elemType returns the type constraints following dive are applied to, see valueType.
+// Unlike the other constraints they reach bytes of a []byte.
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+		return t.Elem()
+	}
+	return valueType(t)
+}
+
+// measuresLength reports whether min, max and len of values of the type t bound their length.
+func measuresLength(t reflect.Type) bool {
+	return t.Kind() == reflect.String || t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// parseInLists parses the in and notin lists for values of the type t, so that the lists aren't parsed
+// on every check. A list which doesn't match t is reported once and dropped.
+func (c *Constraints) parseInLists(t reflect.Type, fieldName string, validationErrors ValidationErrors) ValidationErrors {
+	if !isNumber(t.Kind()) {
+		return validationErrors
+	}
+
+	var err error
+	if c.in != nil {
+		if c.inNums, err = parseNumList(c.in, "in", t); err != nil {
+			c.in = nil
+			validationErrors = append(validationErrors, ValidationError{Err: err, FieldName: fieldName, Rule: "in"})
+		}
+	}
+	if c.notin != nil {
+		if c.notinNums, err = parseNumList(c.notin, "notin", t); err != nil {
+			c.notin = nil
+			validationErrors = append(validationErrors, ValidationError{Err: err, FieldName: fieldName, Rule: "notin"})
+		}
+	}
+	return validationErrors
+}
+
+// dropInapplicable reports constraints which have no meaning for values of the type t once and drops them,
+// so that they aren't reported for every element: len of floats, len, in and notin of complex numbers,
+// negative or negative bounds of unsigned integers, and constraints the checker of the kind ignores,
+// e.g. email of an integer or in of a []byte.
+// Values whose type wasn't known then, e.g. behind an interface, are checked for them on validation.
+// entries is set for constraints of a map itself, its len, min and max bound the number of entries.
+func (c *Constraints) dropInapplicable(t reflect.Type, entries bool, fieldName string, validationErrors ValidationErrors) ValidationErrors {
+	switch k := t.Kind(); {
+	case (k == reflect.Float32 || k == reflect.Float64) && c.len != -1 && !entries:
+		validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "len isn't applicable to floats"), FieldName: fieldName, Rule: "len"})
+		c.len = -1
+	case k == reflect.Complex64 || k == reflect.Complex128:
+		if c.len != -1 && !entries {
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "len isn't applicable to complex numbers"), FieldName: fieldName, Rule: "len"})
+			c.len = -1
+		}
+		if c.in != nil {
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "in isn't applicable to complex numbers"), FieldName: fieldName, Rule: "in"})
+			c.in = nil
+		}
+		if c.notin != nil {
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "notin isn't applicable to complex numbers"), FieldName: fieldName, Rule: "notin"})
+			c.notin = nil
+		}
+	case isUint(k):
+		if c.max != nil && *c.max < 0 && !entries {
+			validationErrors = append(validationErrors, negativeUintBound(fieldName, "max", *c.max))
+			c.max, c.uintBounds.max = nil, nil
+		}
+		if c.min != nil && *c.min < 0 && !entries {
+			validationErrors = append(validationErrors, negativeUintBound(fieldName, "min", *c.min))
+			c.min, c.uintBounds.min = nil, nil
+		}
+		if c.negative {
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "negative isn't applicable to unsigned integers"), FieldName: fieldName, Rule: "negative"})
+			c.negative = false
+		}
+	}
+
+	// the other constraints ignored by the checker of the kind are reported unless IgnoreUnsupportedKinds is set
+	if rules, kinds := kindRules(t); rules != nil && !c.validator().IgnoreUnsupportedKinds {
+		validationErrors = c.dropRules(func(rule string) bool {
+			return rules[rule] || entries && (rule == "len" || rule == "min" || rule == "max")
+		}, kinds, fieldName, validationErrors)
+	}
+	return validationErrors
+}
+
+// rule sets of kinds, see kindRules
+var (
+	stringRules  = ruleSet("min max len in notin fold trim email ip hostname fqdn url uuid json alpha numeric alphanumeric lowercase uppercase strmin strmax contains prefix suffix regexp")
+	intRules     = ruleSet("min max len gt lt positive negative multipleof in notin")
+	floatRules   = ruleSet("min max gt lt positive negative multipleof in notin")
+	complexRules = ruleSet("min max gt lt")
+	boolRules    = ruleSet("eq")
+	timeRules    = ruleSet("after before")
+	bytesRules   = ruleSet("min max len")
+)
+
+func ruleSet(rules string) map[string]bool {
+	set := map[string]bool{}
+	for _, rule := range strings.Fields(rules) {
+		set[rule] = true
+	}
+	return set
+}
+
+// kindRules returns the value constraints checked for values of the type t, see valueRules, and the name of such values
+// in messages. The rules are nil for kinds without a checker and for interfaces whose values are known only on validation.
+func kindRules(t reflect.Type) (map[string]bool, string) {
+	switch k := t.Kind(); {
+	case isTime(t):
+		return timeRules, "times"
+	case k == reflect.String:
+		return stringRules, "strings"
+	case isInt(k):
+		return intRules, "integers"
+	case isUint(k):
+		return intRules, "unsigned integers"
+	case k == reflect.Float32 || k == reflect.Float64:
+		return floatRules, "floats"
+	case k == reflect.Complex64 || k == reflect.Complex128:
+		return complexRules, "complex numbers"
+	case k == reflect.Bool:
+		return boolRules, "bools"
+	case k == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		// bytes are checked only by constraints following dive
+		return bytesRules, "byte slices"
+	}
+	return nil, ""
+}
+
+// valueRule is a constraint on the value itself listed by the name of its rule, set reports whether
+// Constraints have it and drop unsets it.
+type valueRule struct {
+	name string
+	set  func(c *Constraints) bool
+	drop func(c *Constraints)
+}
+
+// valueRules lists the constraints checked by the checkers of kinds, the rules of ip, ipv4 and ipv6 are listed as ip.
+// Constraints about the field itself, like required or eqfield, and about a slice or a map as a whole aren't listed.
+var valueRules = []valueRule{
+	{"min", func(c *Constraints) bool { return c.min != nil }, func(c *Constraints) { c.min, c.intBounds.min, c.uintBounds.min = nil, nil, nil }},
+	{"max", func(c *Constraints) bool { return c.max != nil }, func(c *Constraints) { c.max, c.intBounds.max, c.uintBounds.max = nil, nil, nil }},
+	{"len", func(c *Constraints) bool { return c.len != -1 }, func(c *Constraints) { c.len = -1 }},
+	{"gt", func(c *Constraints) bool { return c.gt != nil }, func(c *Constraints) { c.gt, c.intBounds.gt, c.uintBounds.gt = nil, nil, nil }},
+	{"lt", func(c *Constraints) bool { return c.lt != nil }, func(c *Constraints) { c.lt, c.intBounds.lt, c.uintBounds.lt = nil, nil, nil }},
+	{"positive", func(c *Constraints) bool { return c.positive }, func(c *Constraints) { c.positive = false }},
+	{"negative", func(c *Constraints) bool { return c.negative }, func(c *Constraints) { c.negative = false }},
+	{"multipleof", func(c *Constraints) bool { return c.multipleOf != nil }, func(c *Constraints) { c.multipleOf = nil }},
+	{"in", func(c *Constraints) bool { return c.in != nil }, func(c *Constraints) { c.in, c.inNums = nil, nil }},
+	{"notin", func(c *Constraints) bool { return c.notin != nil }, func(c *Constraints) { c.notin, c.notinNums = nil, nil }},
+	{"fold", func(c *Constraints) bool { return c.fold }, func(c *Constraints) { c.fold = false }},
+	{"trim", func(c *Constraints) bool { return c.trim }, func(c *Constraints) { c.trim = false }},
+	{"email", func(c *Constraints) bool { return c.email }, func(c *Constraints) { c.email = false }},
+	{"ip", func(c *Constraints) bool { return c.isIP }, func(c *Constraints) { c.isIP, c.ipVersion = false, 0 }},
+	{"hostname", func(c *Constraints) bool { return c.hostname }, func(c *Constraints) { c.hostname = false }},
+	{"fqdn", func(c *Constraints) bool { return c.fqdn }, func(c *Constraints) { c.fqdn = false }},
+	{"url", func(c *Constraints) bool { return c.isURL }, func(c *Constraints) { c.isURL, c.urlSchemes = false, nil }},
+	{"uuid", func(c *Constraints) bool { return c.uuid }, func(c *Constraints) { c.uuid, c.uuidVersion = false, 0 }},
+	{"json", func(c *Constraints) bool { return c.isJSON }, func(c *Constraints) { c.isJSON = false }},
+	{"alpha", func(c *Constraints) bool { return c.alpha }, func(c *Constraints) { c.alpha = false }},
+	{"numeric", func(c *Constraints) bool { return c.numeric }, func(c *Constraints) { c.numeric = false }},
+	{"alphanumeric", func(c *Constraints) bool { return c.alphanumeric }, func(c *Constraints) { c.alphanumeric = false }},
+	{"lowercase", func(c *Constraints) bool { return c.lowercase }, func(c *Constraints) { c.lowercase = false }},
+	{"uppercase", func(c *Constraints) bool { return c.uppercase }, func(c *Constraints) { c.uppercase = false }},
+	{"strmin", func(c *Constraints) bool { return c.strMin != nil }, func(c *Constraints) { c.strMin = nil }},
+	{"strmax", func(c *Constraints) bool { return c.strMax != nil }, func(c *Constraints) { c.strMax = nil }},
+	{"contains", func(c *Constraints) bool { return c.contains != "" }, func(c *Constraints) { c.contains = "" }},
+	{"prefix", func(c *Constraints) bool { return c.prefix != "" }, func(c *Constraints) { c.prefix = "" }},
+	{"suffix", func(c *Constraints) bool { return c.suffix != "" }, func(c *Constraints) { c.suffix = "" }},
+	{"regexp", func(c *Constraints) bool { return c.pattern != nil }, func(c *Constraints) { c.pattern = nil }},
+	{"eq", func(c *Constraints) bool { return c.eq != nil }, func(c *Constraints) { c.eq = nil }},
+	{"after", func(c *Constraints) bool { return c.after != nil }, func(c *Constraints) { c.after = nil }},
+	{"before", func(c *Constraints) bool { return c.before != nil }, func(c *Constraints) { c.before = nil }},
+}
+
+// dropRules reports and drops every value constraint of c whose rule isn't applicable, kinds names values
+// of the type they are reported for, e.g. "byte slices".
+func (c *Constraints) dropRules(applicable func(rule string) bool, kinds, fieldName string, validationErrors ValidationErrors) ValidationErrors {
+	for _, r := range valueRules {
+		if !r.set(c) || applicable(r.name) {
+			continue
+		}
+		rule := r.name
+		if rule == "ip" {
+			rule = ipRule(c.ipVersion)
+		}
+		validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, rule+" isn't applicable to "+kinds), FieldName: fieldName, Rule: rule})
+		r.drop(c)
+	}
+	return validationErrors
+}
+
+// negativeUintBound reports the negative bound of the rule of an unsigned integer.
+func negativeUintBound(fieldName, rule string, bound float64) ValidationError {
+	return ValidationError{Err: errors.Wrapf(ErrInvalidValidatorSyntax, "%s %s isn't applicable to unsigned integers", rule, formatNum(bound)), FieldName: fieldName, Rule: rule}
+}
+
+// numList is an in or notin list parsed for values of a numeric type, only the slice of its kind is set.
+type numList struct {
+	ints   []int64
+	uints  []uint64
+	floats []float64
+}
+
+// parseNumList parses every value of the rule list for values of the numeric type t,
+// floats are parsed with the precision of t so that "0.1" matches a float32(0.1).
+func parseNumList(list []string, rule string, t reflect.Type) (*numList, error) {
+	nums := &numList{}
+	for _, s := range list {
+		var err error
+		switch k := t.Kind(); {
+		case isInt(k):
+			var num int64
+			num, err = strconv.ParseInt(s, 10, 64)
+			nums.ints = append(nums.ints, num)
+		case isUint(k):
+			var num uint64
+			num, err = strconv.ParseUint(s, 10, 64)
+			nums.uints = append(nums.uints, num)
+		default:
+			var num float64
+			num, err = strconv.ParseFloat(s, t.Bits())
+			nums.floats = append(nums.floats, num)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidValidatorSyntax, "invalid %s value '%s'", rule, s)
+		}
+	}
+	return nums, nil
+}
+
+// containsNum reports whether num is one of nums.
+func containsNum[T comparable](nums []T, num T) bool {
+	for _, n := range nums {
+		if n == num {
+			return true
+		}
+	}
+	return false
+}
+
+// isSliceLevel reports whether the constraint con is about a slice itself rather than its elements.
+func isSliceLevel(con, kvSep string) bool {
+	switch strings.TrimSpace(strings.SplitN(con, kvSep, 2)[0]) {
+	case "minlen", "maxlen", "exactlen", "len_min", "len_max", "unique":
+		return true
+	}
+	return false
+}
+
+// hasStructElems reports whether t is a slice or an array of structs or pointers to structs, time.Time excluded.
+func hasStructElems(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return false
+	}
+	t = t.Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !isTime(t)
+}
+
+func parseConstraint(con string, fieldName string, c *Constraints, validationErrors ValidationErrors) ValidationErrors {
+	trim := !c.validator().PreserveSpaces
+	if trim {
+		con = strings.TrimSpace(con)
+	}
+	kvSep, listSep := c.validator().KVSep, c.validator().ListSep
+
+	if strings.HasPrefix(con, "key=") {
+		if c.keys == nil {
+			keys := NewConstraints()
+			keys.v = c.v
+			c.keys = &keys
+		}
+		return parseConstraint(strings.TrimPrefix(con, "key="), fieldName, c.keys, validationErrors)
+	}
+
+	// oneof comes from go-playground/validator where the value follows "="
+	if strings.HasPrefix(con, "oneof=") {
+		con = "oneof" + kvSep + strings.TrimPrefix(con, "oneof=")
+	}
+
+	s := strings.SplitN(con, kvSep, 2)
+	if trim {
+		for i := range s {
+			s[i] = strings.TrimSpace(s[i])
+		}
+	}
+	if len(s) < 2 {
+		switch s[0] {
+		case "required":
+			c.required = true
+		case "omitempty":
+			c.omitempty = true
+		case "email":
+			c.email = true
+		case "ip":
+			c.isIP = true
+		case "ipv4":
+			c.isIP, c.ipVersion = true, 4
+		case "ipv6":
+			c.isIP, c.ipVersion = true, 6
+		case "hostname":
+			c.hostname = true
+		case "fqdn":
+			c.fqdn = true
+		case "dive":
+			c.dive = true
+		case "nested":
+			c.nested = true
+		case "fold":
+			c.fold = true
+		case "trim":
+			c.trim = true
+		case "url":
+			c.isURL = true
+		case "unique":
+			c.unique = true
+		case "alpha":
+			c.alpha = true
+		case "numeric":
+			c.numeric = true
+		case "alphanumeric":
+			c.alphanumeric = true
+		case "lowercase":
+			c.lowercase = true
+		case "uppercase":
+			c.uppercase = true
+		case "uuid":
+			c.uuid = true
+		case "json":
+			c.isJSON = true
+		// positive and negative are shorthands for gt:0 and lt:0 of numbers
+		case "positive":
+			c.positive = true
+		case "negative":
+			c.negative = true
+		default:
+			if fn, ok := c.validator().lookupValidator(s[0]); ok {
+				c.custom = append(c.custom, customValidator{s[0], fn})
+				return validationErrors
+			}
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "unknown constraint '"+s[0]+"'"), FieldName: fieldName, Rule: s[0]})
+		}
+		return validationErrors
+	}
+
+	switch s[0] {
+	// gte and lte are inclusive, so they are aliases of min and max
+	case "max", "lte":
+		max, err := ParseFloat(s[1])
+		if err != nil {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			c.max = &max
+			c.intBounds.max, c.uintBounds.max = parseExactBound(s[1])
+		}
+	case "min", "gte":
+		min, err := ParseFloat(s[1])
+		if err != nil {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			c.min = &min
+			c.intBounds.min, c.uintBounds.min = parseExactBound(s[1])
+		}
+	case "gt", "lt":
+		bound, err := ParseFloat(s[1])
+		if err != nil {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else if s[0] == "gt" {
+			c.gt = &bound
+			c.intBounds.gt, c.uintBounds.gt = parseExactBound(s[1])
+		} else {
+			c.lt = &bound
+			c.intBounds.lt, c.uintBounds.lt = parseExactBound(s[1])
+		}
+	case "multipleof":
+		step, err := ParseFloat(s[1])
+		if err != nil || !(step > 0) || math.IsInf(step, 0) {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			c.multipleOf = &step
+		}
+	case "range":
+		min, max, err := ParseRange(s[1])
+		if err != nil {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			left, right, _ := splitRange(s[1])
+			c.min, c.max = min, max
+			c.intBounds.min, c.uintBounds.min = parseExactBound(left)
+			c.intBounds.max, c.uintBounds.max = parseExactBound(right)
+		}
+	case "len":
+		l, err := ParseInt(s[1])
+		if err != nil {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else if l < 0 {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			c.len = l
+		}
+	// len_min and len_max are aliases of minlen and maxlen
+	case "minlen", "maxlen", "exactlen", "len_min", "len_max":
+		l, err := ParseInt(s[1])
+		if err != nil {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else if l < 0 {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else if s[0] == "minlen" || s[0] == "len_min" {
+			c.minLen = l
+		} else if s[0] == "maxlen" || s[0] == "len_max" {
+			c.maxLen = l
+		} else {
+			c.exactLen = l
+		}
+	case "in":
+		in := splitList(s[1], listSep, trim)
+		if len(strings.Join(in, "")) == 0 {
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "empty 'in' list"), FieldName: fieldName, Rule: s[0]})
+		} else {
+			c.in = in
+		}
+	case "oneof":
+		// same as in, but values may also be separated by spaces
+		in := strings.Fields(strings.ReplaceAll(s[1], listSep, " "))
+		if len(in) == 0 {
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "empty 'oneof' list"), FieldName: fieldName, Rule: s[0]})
+		} else {
+			c.in = in
+		}
+	case "notin":
+		notin := splitList(s[1], listSep, trim)
+		if len(strings.Join(notin, "")) == 0 {
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "empty 'notin' list"), FieldName: fieldName, Rule: s[0]})
+		} else {
+			c.notin = notin
+		}
+	// strmin and strmax compare the value itself lexicographically, byte by byte,
+	// unlike min and max which bound the length of a string
+	case "strmin":
+		bound := s[1]
+		c.strMin = &bound
+	case "strmax":
+		bound := s[1]
+		c.strMax = &bound
+	case "uuid":
+		version, err := strconv.Atoi(strings.TrimPrefix(s[1], "v"))
+		if err != nil || version < 1 || version > 8 {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			c.uuid = true
+			c.uuidVersion = version
+		}
+	case "url":
+		schemes := splitList(s[1], listSep, trim)
+		if len(strings.Join(schemes, "")) == 0 {
+			validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "empty 'url' list"), FieldName: fieldName, Rule: s[0]})
+		} else {
+			c.isURL = true
+			c.urlSchemes = schemes
+		}
+	case "contains":
+		if s[1] == "" {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			c.contains = s[1]
+		}
+	case "prefix":
+		if s[1] == "" {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			c.prefix = s[1]
+		}
+	case "suffix":
+		if s[1] == "" {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			c.suffix = s[1]
+		}
+	case "eq":
+		eq, err := strconv.ParseBool(s[1])
+		if err != nil {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			c.eq = &eq
+		}
+	case "default":
+		def := s[1]
+		c.def = &def
+	case "eqfield", "gtfield", "ltfield":
+		if s[1] == "" {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			c.fieldRefs = append(c.fieldRefs, fieldRef{rule: s[0], name: s[1]})
+		}
+	case "after":
+		after, err := time.Parse(c.validator().TimeLayout, s[1])
+		if err != nil {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			c.after = &after
+		}
+	case "before":
+		before, err := time.Parse(c.validator().TimeLayout, s[1])
+		if err != nil {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			c.before = &before
+		}
+	case "regexp":
+		re, err := regexp.Compile(s[1])
+		if err != nil {
+			validationErrors = append(validationErrors, invalidValue(fieldName, s[0], s[1]))
+		} else {
+			c.pattern = re
+		}
+	default:
+		// custom validators take no value, so "phone:us" is as unknown as a typo like "mxa:5"
+		validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "unknown constraint '"+s[0]+"'"), FieldName: fieldName, Rule: s[0]})
+	}
+
+	return validationErrors
+}
+
+// splitList splits a list of an in or a notin constraint separated by sep, a comma by default. A separator
+// preceded by a backslash is a part of the value and a double backslash is a single one, e.g. `in:Paris\, France,Berlin`.
+// Backslashes in a struct tag are escaped themselves, so the tag is written as "in:Paris\\, France,Berlin".
+// Spaces around values are trimmed when trim is set.
+func splitList(s, sep string, trim bool) []string {
+	var res []string
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '\\' && strings.HasPrefix(s[i+1:], sep):
+			b.WriteString(sep)
+			i += 1 + len(sep)
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == '\\':
+			b.WriteByte('\\')
+			i += 2
+		case strings.HasPrefix(s[i:], sep):
+			res = append(res, b.String())
+			b.Reset()
+			i += len(sep)
+		default:
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+	res = append(res, b.String())
+
+	if trim {
+		for i := range res {
+			res[i] = strings.TrimSpace(res[i])
+		}
+	}
+	return res
+}
+
+func CheckConstraints(val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) ValidationErrors {
+	return checkConstraints(context.Background(), val, fieldName, constraints, 0, validationErrors)
+}
+
+// checkConstraints works like CheckConstraints, ctx is passed to custom validators. Elements of slices and maps
+// aren't checked anymore once there are limit errors, there is no limit when it's 0.
+func checkConstraints(ctx context.Context, val reflect.Value, fieldName string, constraints Constraints, limit int, validationErrors ValidationErrors) ValidationErrors {
+	if constraints.required && val.IsZero() {
+		return append(validationErrors, constraints.failure(fieldName, "required", "value is required"))
+	}
+
+	if constraints.omitempty && val.IsZero() {
+		return validationErrors
+	}
+
+	for _, c := range constraints.custom {
+		if err := c.fn(ctx, val); err != nil {
+			validationErrors = append(validationErrors, ValidationError{Err: err, FieldName: fieldName, Rule: c.name})
+		}
+	}
+
+	if val.Kind() == reflect.Ptr {
+		return checkPtrConstraints(ctx, val, fieldName, constraints, limit, validationErrors)
+	}
+
+	if isTime(val.Type()) {
+		return checkTimeConstraints(val, fieldName, constraints, validationErrors)
+	}
+
+	if val.Kind() == reflect.String {
+		return checkStringConstraints(val, fieldName, constraints, validationErrors)
+	}
+
+	if isInt(val.Kind()) {
+		return checkIntConstraints(val, fieldName, constraints, validationErrors)
+	}
+
+	if isUint(val.Kind()) {
+		return checkUintConstraints(val, fieldName, constraints, validationErrors)
+	}
+
+	if val.Kind() == reflect.Float32 || val.Kind() == reflect.Float64 {
+		return checkFloatConstraints(val, fieldName, constraints, validationErrors)
+	}
+
+	if val.Kind() == reflect.Complex64 || val.Kind() == reflect.Complex128 {
+		return checkComplexConstraints(val, fieldName, constraints, validationErrors)
+	}
+
+	if val.Kind() == reflect.Bool {
+		return checkBoolConstraints(val, fieldName, constraints, validationErrors)
+	}
+
+	if val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.Uint8 {
+		return checkBytesConstraints(ctx, val, fieldName, constraints, limit, validationErrors)
+	}
+
+	if val.Kind() == reflect.Slice || val.Kind() == reflect.Array {
+		return checkSliceConstraints(ctx, val, fieldName, constraints, limit, validationErrors)
+	}
+
+	if val.Kind() == reflect.Map {
+		return checkMapConstraints(ctx, val, fieldName, constraints, limit, validationErrors)
+	}
+
+	if !constraints.validator().IgnoreUnsupportedKinds && constraints.hasValueConstraints() {
+		return append(validationErrors, ValidationError{Err: errors.New("constraints not applicable to kind " + val.Kind().String()), FieldName: fieldName})
+	}
+
+	return validationErrors
+}
+
+func checkStringConstraints(val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) ValidationErrors {
+	str := val.String()
+	if constraints.trim {
+		str = strings.TrimSpace(str)
+	}
+	length := constraints.validator().stringLen(str)
+	if constraints.max != nil && float64(length) > *constraints.max {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "max", "length is "+strconv.Itoa(length)+", can't be more than "+formatNum(*constraints.max)))
+	}
+	if constraints.min != nil && float64(length) < *constraints.min {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "min", "length is "+strconv.Itoa(length)+", can't be less than "+formatNum(*constraints.min)))
+	}
+	if constraints.len != -1 && length != constraints.len {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "len", "length is "+strconv.Itoa(length)+", must be "+strconv.Itoa(constraints.len)))
+	}
+
+	// an empty value is allowed, use required to forbid it
+	if constraints.isIP && val.String() != "" {
+		if version := ipVersion(val.String()); version == 0 {
+			validationErrors = append(validationErrors, constraints.failure(fieldName, ipRule(constraints.ipVersion), "value is not a valid ip address"))
+		} else if constraints.ipVersion != 0 && version != constraints.ipVersion {
+			validationErrors = append(validationErrors, constraints.failure(fieldName, ipRule(constraints.ipVersion), "value is not a valid ipv"+strconv.Itoa(constraints.ipVersion)+" address"))
+		}
+	}
+
+	if constraints.hostname && val.String() != "" && !isHostname(val.String(), false) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "hostname", "value is not a valid hostname"))
+	}
+
+	if constraints.fqdn && val.String() != "" && !isHostname(val.String(), true) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "fqdn", "value is not a valid fully qualified domain name"))
+	}
+
+	if constraints.email && val.String() != "" {
+		if addr, err := mail.ParseAddress(val.String()); err != nil || addr.Address != val.String() {
+			validationErrors = append(validationErrors, constraints.failure(fieldName, "email", "value is not a valid email address"))
+		}
+	}
+
+	if constraints.isURL && val.String() != "" {
+		u, err := url.ParseRequestURI(val.String())
+		if err != nil || u.Scheme == "" {
+			validationErrors = append(validationErrors, constraints.failure(fieldName, "url", "value is not a valid absolute url"))
+		} else if constraints.urlSchemes != nil {
+			var find bool
+			for _, scheme := range constraints.urlSchemes {
+				if strings.EqualFold(u.Scheme, scheme) {
+					find = true
+					break
+				}
+			}
+			if !find {
+				validationErrors = append(validationErrors, constraints.failure(fieldName, "url", "url scheme must be one of "+strings.Join(constraints.urlSchemes, ",")))
+			}
+		}
+	}
+
+	if constraints.strMin != nil && val.String() < *constraints.strMin {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "strmin", "value can't be less than '"+*constraints.strMin+"'"))
+	}
+	if constraints.strMax != nil && val.String() > *constraints.strMax {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "strmax", "value can't be more than '"+*constraints.strMax+"'"))
+	}
+
+	// an empty value is allowed, use required to forbid it
+	if constraints.alpha && !onlyRunes(val.String(), unicode.IsLetter) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "alpha", "value must contain only letters"))
+	}
+	if constraints.numeric && !onlyRunes(val.String(), unicode.IsDigit) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "numeric", "value must contain only digits"))
+	}
+	if constraints.alphanumeric && !onlyRunes(val.String(), func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "alphanumeric", "value must contain only letters and digits"))
+	}
+	if constraints.lowercase && val.String() != strings.ToLower(val.String()) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "lowercase", "value must be in lower case"))
+	}
+	if constraints.uppercase && val.String() != strings.ToUpper(val.String()) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "uppercase", "value must be in upper case"))
+	}
+
+	if constraints.isJSON && val.String() != "" && !json.Valid([]byte(val.String())) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "json", "value is not a valid json"))
+	}
+
+	if constraints.uuid && val.String() != "" {
+		if version, ok := parseUUID(val.String()); !ok {
+			validationErrors = append(validationErrors, constraints.failure(fieldName, "uuid", "value is not a valid uuid"))
+		} else if constraints.uuidVersion != 0 && version != constraints.uuidVersion {
+			validationErrors = append(validationErrors, constraints.failure(fieldName, "uuid", "uuid version must be "+strconv.Itoa(constraints.uuidVersion)))
+		}
+	}
+
+	if constraints.contains != "" && !strings.Contains(val.String(), constraints.contains) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "contains", "value must contain '"+constraints.contains+"'"))
+	}
+
+	if constraints.prefix != "" && !strings.HasPrefix(val.String(), constraints.prefix) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "prefix", "value must start with '"+constraints.prefix+"'"))
+	}
+	if constraints.suffix != "" && !strings.HasSuffix(val.String(), constraints.suffix) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "suffix", "value must end with '"+constraints.suffix+"'"))
+	}
+
+	if constraints.pattern != nil && !constraints.pattern.MatchString(val.String()) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "regexp", "value doesn't match the 'regexp'"))
+	}
+
+	if constraints.in != nil {
+		var find bool
+		for _, s := range constraints.in {
+			if val.String() == s || (constraints.fold && strings.EqualFold(val.String(), s)) {
+				find = true
+				break
+			}
+		}
+		if !find {
+			validationErrors = append(validationErrors, constraints.failure(fieldName, "in", "value is not contained in the 'in'"))
+		}
+	}
+
+	for _, s := range constraints.notin {
+		if val.String() == s || (constraints.fold && strings.EqualFold(val.String(), s)) {
+			validationErrors = append(validationErrors, constraints.failure(fieldName, "notin", "value is contained in the 'notin'"))
+			break
+		}
+	}
+
+	return validationErrors
+}
+
+// parseUUID checks s is in the canonical 8-4-4-4-12 hex form, e.g. "123e4567-e89b-12d3-a456-426614174000",
+// and returns its version digit.
+func parseUUID(s string) (int, bool) {
+	if len(s) != 36 {
+		return 0, false
+	}
+	for i := 0; i < len(s); i++ {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if s[i] != '-' {
+				return 0, false
+			}
+			continue
+		}
+		if !strings.ContainsRune("0123456789abcdefABCDEF", rune(s[i])) {
+			return 0, false
+		}
+	}
+
+	version, _ := strconv.ParseInt(s[14:15], 16, 0)
+	return int(version), true
+}
+
+// ipVersion returns 4 or 6 for an ip address in the dotted or in the colon notation, and 0 for a malformed one.
+// An ipv4-mapped ipv6 address like "::ffff:1.2.3.4" is an ipv6 one.
+func ipVersion(s string) int {
+	if net.ParseIP(s) == nil {
+		return 0
+	}
+	if strings.Contains(s, ":") {
+		return 6
+	}
+	return 4
+}
+
+// isHostname reports whether s is a hostname by RFC 1123: dot separated labels of 1 to 63 letters,
+// digits and hyphens not starting or ending with a hyphen, 253 characters at most.
+// A fully qualified name has at least two labels and may end with the dot of the root.
+func isHostname(s string, fqdn bool) bool {
+	if fqdn {
+		s = strings.TrimSuffix(s, ".")
+	}
+	if len(s) == 0 || len(s) > 253 {
+		return false
+	}
+
+	labels := strings.Split(s, ".")
+	if fqdn && len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			if c := label[i]; c != '-' && !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ipRule returns the name of the ip constraint of the version, 0 means any version.
+func ipRule(version int) string {
+	if version == 0 {
+		return "ip"
+	}
+	return "ipv" + strconv.Itoa(version)
+}
+
+// onlyRunes reports whether every rune of s satisfies f, it stops on the first one which doesn't.
+func onlyRunes(s string, f func(rune) bool) bool {
+	for _, r := range s {
+		if !f(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// stringLen returns the length of s in runes, or in bytes when CountRunes is turned off.
+func (v *Validator) stringLen(s string) int {
+	if v.CountRunes {
+		return utf8.RuneCountInString(s)
+	}
+	return len(s)
+}
+
+func checkIntConstraints(val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) ValidationErrors {
+	bounds := constraints.intBounds
+	if constraints.max != nil && compareBound(val.Int(), *constraints.max, bounds.max) > 0 {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "max", "value is "+strconv.FormatInt(val.Int(), 10)+", can't be more than "+formatBound(*constraints.max, bounds.max)))
+	}
+	if constraints.min != nil && compareBound(val.Int(), *constraints.min, bounds.min) < 0 {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "min", "value is "+strconv.FormatInt(val.Int(), 10)+", can't be less than "+formatBound(*constraints.min, bounds.min)))
+	}
+	if constraints.gt != nil && compareBound(val.Int(), *constraints.gt, bounds.gt) <= 0 {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "gt", "value is "+strconv.FormatInt(val.Int(), 10)+", must be strictly greater than "+formatBound(*constraints.gt, bounds.gt)))
+	}
+	if constraints.lt != nil && compareBound(val.Int(), *constraints.lt, bounds.lt) >= 0 {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "lt", "value is "+strconv.FormatInt(val.Int(), 10)+", must be strictly less than "+formatBound(*constraints.lt, bounds.lt)))
+	}
+	if constraints.positive && val.Int() <= 0 {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "positive", "value must be positive"))
+	}
+	if constraints.negative && val.Int() >= 0 {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "negative", "value must be negative"))
+	}
+	if constraints.multipleOf != nil && !isIntMultipleOf(val.Int(), *constraints.multipleOf) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "multipleof", "value is "+strconv.FormatInt(val.Int(), 10)+", must be a multiple of "+formatNum(*constraints.multipleOf)))
+	}
+	// len on integers is the number of decimal digits, the sign isn't counted
+	if digits := len(strings.TrimPrefix(strconv.FormatInt(val.Int(), 10), "-")); constraints.len != -1 && digits != constraints.len {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "len", "number of digits is "+strconv.Itoa(digits)+", must be "+strconv.Itoa(constraints.len)))
+	}
+
+	return checkNumLists(val, fieldName, constraints, func(in *numList) bool { return containsNum(in.ints, val.Int()) }, validationErrors)
+}
+
+// checkNumLists checks val against the in and notin lists of constraints with contains,
+// which reports whether val is in the given list.
+func checkNumLists(val reflect.Value, fieldName string, constraints Constraints, contains func(*numList) bool, validationErrors ValidationErrors) ValidationErrors {
+	// the lists are parsed with the tag unless the type of the value wasn't known then
+	if constraints.in != nil {
+		in, err := constraints.inNums, error(nil)
+		if in == nil {
+			in, err = parseNumList(constraints.in, "in", val.Type())
+		}
+		if err != nil {
+			validationErrors = append(validationErrors, ValidationError{Err: err, FieldName: fieldName, Rule: "in"})
+		} else if !contains(in) {
+			validationErrors = append(validationErrors, constraints.failure(fieldName, "in", "value is not contained in the 'in'"))
+		}
+	}
+	if constraints.notin != nil {
+		notin, err := constraints.notinNums, error(nil)
+		if notin == nil {
+			notin, err = parseNumList(constraints.notin, "notin", val.Type())
+		}
+		if err != nil {
+			validationErrors = append(validationErrors, ValidationError{Err: err, FieldName: fieldName, Rule: "notin"})
+		} else if contains(notin) {
+			validationErrors = append(validationErrors, constraints.failure(fieldName, "notin", "value is contained in the 'notin'"))
+		}
+	}
+	return validationErrors
+}
+
+func checkUintConstraints(val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) ValidationErrors {
+	// a negative bound can't apply to an unsigned value, it's reported with the tag unless the type wasn't known then
+	if constraints.max != nil && *constraints.max < 0 {
+		return append(validationErrors, negativeUintBound(fieldName, "max", *constraints.max))
+	}
+	if constraints.min != nil && *constraints.min < 0 {
+		return append(validationErrors, negativeUintBound(fieldName, "min", *constraints.min))
+	}
+	if constraints.negative {
+		return append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "negative isn't applicable to unsigned integers"), FieldName: fieldName, Rule: "negative"})
+	}
+
+	bounds := constraints.uintBounds
+	if constraints.max != nil && compareBound(val.Uint(), *constraints.max, bounds.max) > 0 {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "max", "value is "+strconv.FormatUint(val.Uint(), 10)+", can't be more than "+formatBound(*constraints.max, bounds.max)))
+	}
+	if constraints.min != nil && compareBound(val.Uint(), *constraints.min, bounds.min) < 0 {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "min", "value is "+strconv.FormatUint(val.Uint(), 10)+", can't be less than "+formatBound(*constraints.min, bounds.min)))
+	}
+	if constraints.gt != nil && compareBound(val.Uint(), *constraints.gt, bounds.gt) <= 0 {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "gt", "value is "+strconv.FormatUint(val.Uint(), 10)+", must be strictly greater than "+formatBound(*constraints.gt, bounds.gt)))
+	}
+	if constraints.lt != nil && compareBound(val.Uint(), *constraints.lt, bounds.lt) >= 0 {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "lt", "value is "+strconv.FormatUint(val.Uint(), 10)+", must be strictly less than "+formatBound(*constraints.lt, bounds.lt)))
+	}
+	if constraints.positive && val.Uint() == 0 {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "positive", "value must be positive"))
+	}
+	if constraints.multipleOf != nil && !isUintMultipleOf(val.Uint(), *constraints.multipleOf) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "multipleof", "value is "+strconv.FormatUint(val.Uint(), 10)+", must be a multiple of "+formatNum(*constraints.multipleOf)))
+	}
+	if digits := len(strconv.FormatUint(val.Uint(), 10)); constraints.len != -1 && digits != constraints.len {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "len", "number of digits is "+strconv.Itoa(digits)+", must be "+strconv.Itoa(constraints.len)))
+	}
+
+	return checkNumLists(val, fieldName, constraints, func(in *numList) bool { return containsNum(in.uints, val.Uint()) }, validationErrors)
+}
+
+func checkFloatConstraints(val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) ValidationErrors {
+	if constraints.max != nil && val.Float() > *constraints.max {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "max", "value is "+formatNum(val.Float())+", can't be more than "+formatNum(*constraints.max)))
+	}
+	if constraints.min != nil && val.Float() < *constraints.min {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "min", "value is "+formatNum(val.Float())+", can't be less than "+formatNum(*constraints.min)))
+	}
+	if constraints.gt != nil && val.Float() <= *constraints.gt {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "gt", "value is "+formatNum(val.Float())+", must be strictly greater than "+formatNum(*constraints.gt)))
+	}
+	if constraints.lt != nil && val.Float() >= *constraints.lt {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "lt", "value is "+formatNum(val.Float())+", must be strictly less than "+formatNum(*constraints.lt)))
+	}
+	if constraints.positive && !(val.Float() > 0) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "positive", "value must be positive"))
+	}
+	if constraints.negative && !(val.Float() < 0) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "negative", "value must be negative"))
+	}
+	if constraints.multipleOf != nil && !isMultipleOf(val.Float(), *constraints.multipleOf) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "multipleof", "value is "+formatNum(val.Float())+", must be a multiple of "+formatNum(*constraints.multipleOf)))
+	}
+	// len is reported with the tag unless the type of the value wasn't known then
+	if constraints.len != -1 {
+		validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "len isn't applicable to floats"), FieldName: fieldName, Rule: "len"})
+	}
+
+	// values are compared for exact equality
+	return checkNumLists(val, fieldName, constraints, func(in *numList) bool { return containsNum(in.floats, val.Float()) }, validationErrors)
+}
+
+// formatNum formats a bound or a float value for a message, e.g. 10 rather than 1e+01.
+func formatNum(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// isMultipleOf reports whether x is an integer multiple of step, the quotient is compared with
+// a tolerance as steps like 0.1 have no exact binary representation.
+func isMultipleOf(x, step float64) bool {
+	q := x / step
+	return math.Abs(q-math.Round(q)) <= 1e-9
+}
+
+// isIntMultipleOf works like isMultipleOf, but integer steps are checked exactly.
+// A step beyond the range of int64 is a multiple of nothing but 0, and of -2^63 when it's 2^63.
+func isIntMultipleOf(x int64, step float64) bool {
+	if step >= math.MaxInt64 {
+		return x == 0 || -float64(x) == step
+	}
+	if step == math.Trunc(step) {
+		return x%int64(step) == 0
+	}
+	return isMultipleOf(float64(x), step)
+}
+
+// isUintMultipleOf works like isMultipleOf, but integer steps are checked exactly.
+// A step beyond the range of uint64 is a multiple of nothing but 0.
+func isUintMultipleOf(x uint64, step float64) bool {
+	if step >= math.MaxUint64 {
+		return x == 0
+	}
+	if step == math.Trunc(step) {
+		return x%uint64(step) == 0
+	}
+	return isMultipleOf(float64(x), step)
+}
+
+// checkComplexConstraints applies bounds to the magnitude |z| of a complex number,
+// in, notin and len have no meaning for complex numbers.
+func checkComplexConstraints(val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) ValidationErrors {
+	abs := cmplx.Abs(val.Complex())
+	if constraints.max != nil && abs > *constraints.max {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "max", "magnitude is "+formatNum(abs)+", can't be more than "+formatNum(*constraints.max)))
+	}
+	if constraints.min != nil && abs < *constraints.min {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "min", "magnitude is "+formatNum(abs)+", can't be less than "+formatNum(*constraints.min)))
+	}
+	if constraints.gt != nil && abs <= *constraints.gt {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "gt", "magnitude is "+formatNum(abs)+", must be strictly greater than "+formatNum(*constraints.gt)))
+	}
+	if constraints.lt != nil && abs >= *constraints.lt {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "lt", "magnitude is "+formatNum(abs)+", must be strictly less than "+formatNum(*constraints.lt)))
+	}
+
+	// len, in and notin are reported with the tag unless the type of the value wasn't known then
+	if constraints.len != -1 {
+		validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "len isn't applicable to complex numbers"), FieldName: fieldName, Rule: "len"})
+	}
+	if constraints.in != nil {
+		validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "in isn't applicable to complex numbers"), FieldName: fieldName, Rule: "in"})
+	}
+	if constraints.notin != nil {
+		validationErrors = append(validationErrors, ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "notin isn't applicable to complex numbers"), FieldName: fieldName, Rule: "notin"})
+	}
+	return validationErrors
+}
+
+func checkBoolConstraints(val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) ValidationErrors {
+	if constraints.eq != nil && val.Bool() != *constraints.eq {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "eq", "value must be equal to "+strconv.FormatBool(*constraints.eq)))
+	}
+
+	return validationErrors
+}
+
+// checkPtrConstraints applies constraints to the pointee, a nil pointer is treated as an absent value.
+func checkPtrConstraints(ctx context.Context, val reflect.Value, fieldName string, constraints Constraints, limit int, validationErrors ValidationErrors) ValidationErrors {
+	if val.IsNil() {
+		return validationErrors
+	}
+
+	// required, omitempty and custom validators are about the pointer itself, they are already checked,
+	// so a pointer to a zero value isn't empty
+	constraints.required, constraints.omitempty = false, false
+	constraints.custom = nil
+	return checkConstraints(ctx, val.Elem(), fieldName, constraints, limit, validationErrors)
+}
+
+func checkTimeConstraints(val reflect.Value, fieldName string, constraints Constraints, validationErrors ValidationErrors) ValidationErrors {
+	if constraints.after == nil && constraints.before == nil {
+		return validationErrors
+	}
+
+	t := val.Convert(timeType).Interface().(time.Time)
+	if constraints.after != nil && !t.After(*constraints.after) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "after", "time must be after "+constraints.after.Format(constraints.validator().TimeLayout)))
+	}
+	if constraints.before != nil && !t.Before(*constraints.before) {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "before", "time must be before "+constraints.before.Format(constraints.validator().TimeLayout)))
+	}
+
+	return validationErrors
+}
+
+// checkSliceConstraints checks the number of elements against minlen, maxlen and exactlen
+// and applies the rest of constraints to every element of a slice or an array.
+func checkSliceConstraints(ctx context.Context, val reflect.Value, fieldName string, constraints Constraints, limit int, validationErrors ValidationErrors) ValidationErrors {
+	if constraints.maxLen != -1 && val.Len() > constraints.maxLen {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "maxlen", "number of elements is "+strconv.Itoa(val.Len())+", can't be more than "+strconv.Itoa(constraints.maxLen)))
+	}
+	if constraints.minLen != -1 && val.Len() < constraints.minLen {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "minlen", "number of elements is "+strconv.Itoa(val.Len())+", can't be less than "+strconv.Itoa(constraints.minLen)))
+	}
+	if constraints.exactLen != -1 && val.Len() != constraints.exactLen {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "exactlen", "number of elements is "+strconv.Itoa(val.Len())+", must be "+strconv.Itoa(constraints.exactLen)))
+	}
+
+	if constraints.unique {
+		validationErrors = checkUnique(val, fieldName, constraints, limit, validationErrors)
+	}
+
+	// struct elements are checked by the walker together with their fields, see (*walker).dive
+	if constraints.dive && hasStructElems(val.Type()) {
+		return validationErrors
+	}
+
+	// required, custom validators, element count and uniqueness are about the slice itself, they are already checked
+	constraints.required = false
+	constraints.custom = nil
+	constraints.minLen, constraints.maxLen, constraints.exactLen = -1, -1, -1
+	constraints.unique = false
+	constraints.omitempty = false
+	if constraints.elem != nil {
+		constraints = *constraints.elem
+	}
+	for i := 0; i < val.Len() && !reached(limit, validationErrors); i++ {
+		validationErrors = checkConstraints(ctx, val.Index(i), indexPath(fieldName, i), constraints, limit, validationErrors)
+	}
+
+	return validationErrors
+}
+
+// checkUnique reports every value occurring more than once in the slice val with the indices
+// of all its occurrences, values are reported in the order of their first occurrence.
+func checkUnique(val reflect.Value, fieldName string, constraints Constraints, limit int, validationErrors ValidationErrors) ValidationErrors {
+	notComparable := ValidationError{Err: errors.Wrap(ErrInvalidValidatorSyntax, "unique is applicable only to slices of comparable elements"), FieldName: fieldName, Rule: "unique"}
+	if !val.Type().Elem().Comparable() {
+		return append(validationErrors, notComparable)
+	}
+
+	// occurrences holds the indices of every distinct value, seen maps a value to its position in occurrences
+	var occurrences [][]int
+	seen := make(map[any]int, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		elem := val.Index(i)
+		if !isComparable(elem) {
+			return append(validationErrors, notComparable)
+		}
+
+		pos := -1
+		if elem.CanInterface() {
+			if j, ok := seen[elem.Interface()]; ok {
+				pos = j
+			} else {
+				seen[elem.Interface()] = len(occurrences)
+			}
+		} else {
+			// values reached through unexported embedded structs can't be used as map keys
+			for j := 0; j < len(occurrences) && pos == -1; j++ {
+				if val.Index(occurrences[j][0]).Equal(elem) {
+					pos = j
+				}
+			}
+		}
+
+		if pos == -1 {
+			occurrences = append(occurrences, []int{i})
+		} else {
+			occurrences[pos] = append(occurrences[pos], i)
+		}
+	}
+
+	for _, indices := range occurrences {
+		if reached(limit, validationErrors) {
+			break
+		}
+		if len(indices) < 2 {
+			continue
+		}
+		msg := fmt.Sprintf("duplicate value '%v' at indices %s", val.Index(indices[0]), joinIndices(indices))
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "unique", msg))
+	}
+
+	return validationErrors
+}
+
+// isComparable reports whether val can be compared with ==, unlike Type.Comparable it looks at values of interfaces,
+// including the ones in struct fields and array elements, e.g. a struct{ X any } holding a slice isn't comparable.
+func isComparable(val reflect.Value) bool {
+	switch val.Kind() {
+	case reflect.Interface:
+		return val.IsNil() || isComparable(val.Elem())
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			if !isComparable(val.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			if !isComparable(val.Index(i)) {
+				return false
+			}
+		}
+		return true
+	default:
+		return val.Type().Comparable()
+	}
+}
+
+// joinIndices lists indices like "1, 3 and 4".
+func joinIndices(indices []int) string {
+	parts := make([]string, len(indices))
+	for i, index := range indices {
+		parts[i] = strconv.Itoa(index)
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+}
+
+// checkBytesConstraints applies min, max and len to the length of a []byte like to a string,
+// bytes themselves are checked only with constraints following dive. The other value constraints
+// are reported with the tag, see dropInapplicable.
+func checkBytesConstraints(ctx context.Context, val reflect.Value, fieldName string, constraints Constraints, limit int, validationErrors ValidationErrors) ValidationErrors {
+	length := val.Len()
+	if constraints.max != nil && float64(length) > *constraints.max {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "max", "length is "+strconv.Itoa(length)+", can't be more than "+formatNum(*constraints.max)))
+	}
+	if constraints.min != nil && float64(length) < *constraints.min {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "min", "length is "+strconv.Itoa(length)+", can't be less than "+formatNum(*constraints.min)))
+	}
+	if constraints.len != -1 && length != constraints.len {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "len", "length is "+strconv.Itoa(length)+", must be "+strconv.Itoa(constraints.len)))
+	}
+
+	slice := NewConstraints()
+	slice.v = constraints.v
+	slice.minLen, slice.maxLen, slice.exactLen = constraints.minLen, constraints.maxLen, constraints.exactLen
+	slice.unique, slice.elem = constraints.unique, constraints.elem
+	return checkSliceConstraints(ctx, val, fieldName, slice, limit, validationErrors)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// isTime reports whether t is time.Time or a type defined on top of it.
+func isTime(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.ConvertibleTo(timeType)
+}
+
+func isInt(k reflect.Kind) bool {
+	return k == reflect.Int || k == reflect.Int8 || k == reflect.Int16 || k == reflect.Int32 || k == reflect.Int64
+}
+
+func isUint(k reflect.Kind) bool {
+	return k == reflect.Uint || k == reflect.Uint8 || k == reflect.Uint16 || k == reflect.Uint32 || k == reflect.Uint64
+}
+
+// isMap reports whether t is a map or a pointer to a map.
+func isMap(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Map
+}
+
+// mapKey returns the key type of the map, or of the map behind the pointer, t.
+func mapKey(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Key()
+}
+
+// isNumber reports whether in and notin lists of k are lists of numbers.
+func isNumber(k reflect.Kind) bool {
+	return isInt(k) || isUint(k) || k == reflect.Float32 || k == reflect.Float64
+}
+
+// checkMapConstraints checks the number of entries of the map against len, min, max, minlen, maxlen and exactlen,
+// then applies the other constraints, or the ones following dive, to every value and constraints prefixed
+// with "key=" to every key, keys are visited in sorted order.
+func checkMapConstraints(ctx context.Context, val reflect.Value, fieldName string, constraints Constraints, limit int, validationErrors ValidationErrors) ValidationErrors {
+	if constraints.len != -1 && val.Len() != constraints.len {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "len", "number of entries is "+strconv.Itoa(val.Len())+", must be "+strconv.Itoa(constraints.len)))
+	}
+	if constraints.max != nil && float64(val.Len()) > *constraints.max {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "max", "number of entries is "+strconv.Itoa(val.Len())+", can't be more than "+formatNum(*constraints.max)))
+	}
+	if constraints.min != nil && float64(val.Len()) < *constraints.min {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "min", "number of entries is "+strconv.Itoa(val.Len())+", can't be less than "+formatNum(*constraints.min)))
+	}
+	if constraints.exactLen != -1 && val.Len() != constraints.exactLen {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "exactlen", "number of entries is "+strconv.Itoa(val.Len())+", must be "+strconv.Itoa(constraints.exactLen)))
+	}
+	if constraints.maxLen != -1 && val.Len() > constraints.maxLen {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "maxlen", "number of entries is "+strconv.Itoa(val.Len())+", can't be more than "+strconv.Itoa(constraints.maxLen)))
+	}
+	if constraints.minLen != -1 && val.Len() < constraints.minLen {
+		validationErrors = append(validationErrors, constraints.failure(fieldName, "minlen", "number of entries is "+strconv.Itoa(val.Len())+", can't be less than "+strconv.Itoa(constraints.minLen)))
+	}
+
+	keyConstraints := constraints.keys
+	constraints.keys = nil
+	constraints.required = false
+	constraints.custom = nil
+	constraints.len, constraints.minLen, constraints.maxLen, constraints.exactLen = -1, -1, -1, -1
+	constraints.min, constraints.max = nil, nil
+	constraints.intBounds, constraints.uintBounds = exactBounds[int64]{}, exactBounds[uint64]{}
+	if constraints.elem != nil {
+		constraints = *constraints.elem
+	}
+
+	keys := val.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	for _, k := range keys {
+		if reached(limit, validationErrors) {
+			break
+		}
+		if keyConstraints != nil {
+			validationErrors = checkConstraints(ctx, k, mapKeyPath(fieldName, k), *keyConstraints, limit, validationErrors)
+		}
+		validationErrors = checkConstraints(ctx, val.MapIndex(k), mapValuePath(fieldName, k), constraints, limit, validationErrors)
+	}
+
+	return validationErrors
+}
+
+func ParseInt(s string) (int, error) {
+	val, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, ErrInvalidValidatorSyntax
+	}
+
+	return val, nil
+}
+
+func ParseFloat(s string) (float64, error) {
+	val, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, ErrInvalidValidatorSyntax
+	}
+
+	return val, nil
+}
+
+// ParseRange parses "min-max" bounds where either end may be omitted, e.g. "1-10", "1-" or "-10".
+// A missing end is returned as nil, bounds themselves may be negative, e.g. "-10--5".
+func ParseRange(s string) (*float64, *float64, error) {
+	left, right, err := splitRange(s)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var min, max *float64
+	if left != "" {
+		bound, _ := ParseFloat(left)
+		min = &bound
+	}
+	if right != "" {
+		bound, _ := ParseFloat(right)
+		max = &bound
+	}
+	if min != nil && max != nil && *min > *max {
+		return nil, nil, ErrInvalidValidatorSyntax
+	}
+	return min, max, nil
+}
+
+// splitRange splits "min-max" bounds on the first hyphen which leaves a number or nothing on both sides.
+func splitRange(s string) (string, string, error) {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '-' {
+			continue
+		}
+
+		left, right := s[:i], s[i+1:]
+		if left == "" && right == "" {
+			break
+		}
+		if _, err := ParseFloat(left); left != "" && err != nil {
+			continue
+		}
+		if _, err := ParseFloat(right); right != "" && err != nil {
+			continue
+		}
+		return left, right, nil
+	}
+
+	return "", "", ErrInvalidValidatorSyntax
+}
+
+// exactBounds holds min, max, gt and lt parsed as integers of type T, as float64 bounds lose precision
+// above 2^53. A bound which isn't a whole number in the range of T is nil, values are compared
+// with its float64 form then.
+type exactBounds[T int64 | uint64] struct {
+	min, max, gt, lt *T
+}
+
+// parseExactBound parses the bound s for values of integer kinds, see exactBounds.
+func parseExactBound(s string) (*int64, *uint64) {
+	var i *int64
+	var u *uint64
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		i = &n
+	}
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		u = &n
+	}
+	return i, u
+}
+
+// compareBound returns -1, 0 or 1 when the integer x is less than, equal to or greater than bound,
+// the exact form of the bound is used when it isn't nil.
+func compareBound[T int64 | uint64](x T, bound float64, exact *T) int {
+	if exact != nil {
+		return compareNums(x, *exact)
+	}
+	return compareNums(float64(x), bound)
+}
+
+// compareNums returns -1, 0 or 1 when a is less than, equal to or greater than b.
+func compareNums[T int64 | uint64 | float64](a, b T) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// formatBound formats a bound of an integer value for a message, see compareBound.
+func formatBound[T int64 | uint64](bound float64, exact *T) string {
+	if exact != nil {
+		return fmt.Sprint(*exact)
+	}
+	return formatNum(bound)
+}
+
+// hasValueConstraints reports whether c has constraints on the value itself,
+// required, omitempty, nested, custom validators, dive, default and cross-field constraints are applicable to a value of any kind.
+func (c Constraints) hasValueConstraints() bool {
+	c.required, c.custom, c.dive, c.def, c.v = false, nil, false, nil, nil
+	c.fieldRefs, c.omitempty, c.nested = nil, false, false
+	return !reflect.DeepEqual(c, NewConstraints())
+}
+
+// validator returns the Validator c was parsed by, constraints built with NewConstraints belong to Default.
+func (c Constraints) validator() *Validator {
+	if c.v == nil {
+		return Default
+	}
+	return c.v
 }
 
 func NewConstraints() Constraints {
-	return Constraints{len: -1, in: nil, min: -1, max: -1}
+	return Constraints{len: -1, in: nil, minLen: -1, maxLen: -1, exactLen: -1}
 }
 
 type Constraints struct {
-	len int
-	in  []string
-	min int
-	max int
+	len   int
+	in    []string
+	notin []string
+	// inNums and notinNums are the in and notin lists parsed for a field of a numeric kind
+	inNums    *numList
+	notinNums *numList
+	min       *float64
+	max       *float64
+	pattern   *regexp.Regexp
+	required  bool
+	// omitempty skips the other constraints of a zero value, required still fails on it
+	omitempty bool
+	eq        *bool
+	custom    []customValidator
+	after     *time.Time
+	before    *time.Time
+	keys      *Constraints
+	email     bool
+	hostname  bool
+	fqdn      bool
+	// isIP requires a string to be an ip address, of ipVersion when it isn't 0
+	isIP      bool
+	ipVersion int
+	isURL     bool
+	// urlSchemes restricts accepted url schemes, any scheme is accepted when it's nil
+	urlSchemes []string
+	// minLen, maxLen and exactLen bound the number of elements of a slice,
+	// unlike min, max and len which are applied to each element
+	minLen   int
+	maxLen   int
+	exactLen int
+	gt       *float64
+	lt       *float64
+	// intBounds and uintBounds are min, max, gt and lt parsed exactly for values of integer kinds
+	intBounds  exactBounds[int64]
+	uintBounds exactBounds[uint64]
+	// dive validates struct elements of a slice with their own tags
+	dive bool
+	// nested marks a struct field validated when ExplicitNested is set,
+	// and an interface field whose value is validated when it's a struct
+	nested bool
+	// elem holds constraints following dive, they are applied to every element instead of the slice
+	elem     *Constraints
+	contains string
+	prefix   string
+	suffix   string
+	// alpha, numeric and alphanumeric restrict characters of a string to letters, digits or both
+	alpha        bool
+	numeric      bool
+	alphanumeric bool
+	// lowercase and uppercase require a string to be equal to its lower or upper case form
+	lowercase bool
+	uppercase bool
+	// strMin and strMax are lexical bounds of a string, min and max bound its length
+	strMin   *string
+	strMax   *string
+	positive bool
+	negative bool
+	// multipleOf requires a number to be an integer multiple of it
+	multipleOf *float64
+	// isJSON requires a string to be a valid json document
+	isJSON bool
+	// uuid requires a string in the canonical uuid form, of uuidVersion when it isn't 0
+	uuid        bool
+	uuidVersion int
+	// unique requires elements of a slice to be distinct
+	unique bool
+	// fold makes in and notin of strings case-insensitive, the lists themselves are parsed as usual
+	fold bool
+	// trim makes min, max and len of strings measure the value without leading and trailing spaces
+	trim bool
+	// fieldRefs are sibling fields the value is compared with
+	fieldRefs []fieldRef
+	// def is set to a zero field before the other constraints are checked
+	def *string
+	// v provides the options of the Validator which parsed the constraints
+	v *Validator
 }
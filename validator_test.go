@@ -1,8 +1,16 @@
 package validator
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -87,7 +95,8 @@ func TestValidate(t *testing.T) {
 			wantErr: true,
 			checkErr: func(err error) bool {
 				e := &ValidationErrors{}
-				return errors.As(err, e) && e.Error() == ErrInvalidValidatorSyntax.Error()
+				return errors.As(err, e) && errors.Is(err, ErrInvalidValidatorSyntax) &&
+					e.Error() == "field: Foo err: invalid len value 'abcdef': "+ErrInvalidValidatorSyntax.Error()
 			},
 		},
 		{
@@ -312,17 +321,3650 @@ func TestValidate(t *testing.T) {
 				return true
 			},
 		},
+		{
+			name: "pointer to correct struct",
+			args: args{v: &struct {
+				A int `validate:"max:5"`
+			}{
+				3,
+			}},
+			wantErr: false,
+		},
+		{
+			name: "pointer to wrong struct",
+			args: args{v: &struct {
+				A int `validate:"max:5"`
+			}{
+				10,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				assert.Len(t, err.(ValidationErrors), 1)
+				return true
+			},
+		},
+		{
+			name: "double pointer to wrong struct",
+			args: args{v: func() any {
+				s := &struct {
+					A string `validate:"len:2"`
+				}{
+					"abc",
+				}
+				return &s
+			}()},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				assert.Len(t, err.(ValidationErrors), 1)
+				return true
+			},
+		},
+		{
+			name: "nil pointer to struct",
+			args: args{v: (*struct {
+				A int `validate:"max:5"`
+			})(nil)},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrNilPointer)
+			},
+		},
+		{
+			name: "correct float",
+			args: args{v: struct {
+				A float64 `validate:"min:1;max:10"`
+				B float32 `validate:"min:0.25;max:0.75"`
+				C float64 `validate:"max:-0.5"`
+			}{
+				5.5,
+				0.5,
+				-1.5,
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong float",
+			args: args{v: struct {
+				A float64 `validate:"min:1;max:10"`
+				B float32 `validate:"min:0.25;max:0.75"`
+				C float64 `validate:"len:2"`
+				D float64 `validate:"max:1.2.3"`
+			}{
+				10.5,
+				0.1,
+				12,
+				1,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				assert.Len(t, err.(ValidationErrors), 4)
+				return true
+			},
+		},
+		{
+			name: "wrong float slice",
+			args: args{v: struct {
+				A []float64 `validate:"min:0;max:1"`
+			}{
+				[]float64{0.5, 1.5, -0.1},
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				assert.Len(t, err.(ValidationErrors), 2)
+				return true
+			},
+		},
+		{
+			name: "correct uint",
+			args: args{v: struct {
+				A uint   `validate:"min:1;max:10"`
+				B uint8  `validate:"max:255"`
+				C uint32 `validate:"in:1,2,3"`
+				D uint64 `validate:"min:100"`
+			}{
+				5,
+				255,
+				2,
+				18446744073709551615,
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong uint",
+			args: args{v: struct {
+				A uint   `validate:"min:1;max:10"`
+				B uint16 `validate:"max:-5"`
+				C uint32 `validate:"in:1,2,3"`
+				D uint64 `validate:"len:3"`
+			}{
+				11,
+				1,
+				4,
+				12,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				assert.Len(t, err.(ValidationErrors), 4)
+				return true
+			},
+		},
+		{
+			name: "correct regexp",
+			args: args{v: struct {
+				A string   `validate:"regexp:^[a-z]+$"`
+				B string   `validate:"regexp:^\\d{2}:\\d{2}$"`
+				C []string `validate:"regexp:^id_\\d+$;min:4"`
+			}{
+				"abc",
+				"12:30",
+				[]string{"id_1", "id_22"},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong regexp",
+			args: args{v: struct {
+				A string   `validate:"regexp:^[a-z]+$"`
+				B string   `validate:"regexp:^\\d{2}:\\d{2}$"`
+				C []string `validate:"regexp:^id_\\d+$"`
+				D string   `validate:"regexp:[a-z"`
+			}{
+				"abc1",
+				"1230",
+				[]string{"id_1", "id_x", "2"},
+				"abc",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				assert.Len(t, err.(ValidationErrors), 5)
+				return true
+			},
+		},
+		{
+			name: "correct values with colons",
+			args: args{v: struct {
+				Layout string `validate:"in:15:04,15:04:05"`
+				URL    string `validate:"in:http://localhost:8080,https://example.com"`
+				Time   string `validate:"regexp:^\\d{2}:\\d{2}:\\d{2}$;len:8"`
+			}{
+				"15:04:05",
+				"http://localhost:8080",
+				"10:20:30",
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong values with colons",
+			args: args{v: struct {
+				Layout string `validate:"in:15:04,15:04:05"`
+				URL    string `validate:"in:http://localhost:8080,https://example.com"`
+			}{
+				"15",
+				"http://localhost",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				assert.Len(t, err.(ValidationErrors), 2)
+				return true
+			},
+		},
+		{
+			name: "malformed constraints",
+			args: args{v: struct {
+				A int    `validate:"max"`
+				B int    `validate:"min:"`
+				C string `validate:"in"`
+				D int    `validate:"max:2;;min:1"`
+			}{
+				1,
+				1,
+				"a",
+				1,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 4)
+				for _, vErr := range e {
+					assert.ErrorIs(t, vErr.Err, ErrInvalidValidatorSyntax)
+				}
+				assert.Equal(t, "A", e[0].FieldName)
+				assert.Contains(t, e[0].Err.Error(), "unknown constraint 'max'")
+				assert.Equal(t, "max", e[0].Rule)
+				assert.Equal(t, "C", e[2].FieldName)
+				assert.Contains(t, e[2].Err.Error(), "unknown constraint 'in'")
+				assert.Equal(t, "in", e[2].Rule)
+				return true
+			},
+		},
+		{
+			name: "correct nested struct",
+			args: args{v: struct {
+				Name    string `validate:"min:2"`
+				Address struct {
+					Zip string `validate:"len:6"`
+				}
+			}{
+				Name: "Alex",
+				Address: struct {
+					Zip string `validate:"len:6"`
+				}{"123456"},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong nested struct",
+			args: args{v: struct {
+				Address struct {
+					Zip string `validate:"len:6"`
+				}
+				Billing *struct {
+					Zip string `validate:"len:6"`
+				}
+				Shipping *struct {
+					Zip string `validate:"len:6"`
+				}
+			}{
+				Address: struct {
+					Zip string `validate:"len:6"`
+				}{"123"},
+				Billing: &struct {
+					Zip string `validate:"len:6"`
+				}{"1234"},
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 2)
+				assert.Equal(t, "Address.Zip", e[0].FieldName)
+				assert.Equal(t, "Billing.Zip", e[1].FieldName)
+				return true
+			},
+		},
+		{
+			name: "self-referential struct",
+			args: args{v: func() any {
+				type node struct {
+					Name string `validate:"min:2"`
+					Next *node
+				}
+				n := &node{Name: "a"}
+				n.Next = &node{Name: "b", Next: n}
+				return n
+			}()},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 2)
+				assert.Equal(t, "Next.Name", e[1].FieldName)
+				return true
+			},
+		},
+		{
+			name: "correct required",
+			args: args{v: struct {
+				A string   `validate:"required;max:10"`
+				B int      `validate:"required"`
+				C []int    `validate:"required;max:5"`
+				D *string  `validate:"required"`
+				E []string `validate:"required"`
+			}{
+				"abc",
+				1,
+				[]int{0, 5},
+				new(string),
+				[]string{""},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong required",
+			args: args{v: struct {
+				A string  `validate:"required;max:10"`
+				B int     `validate:"required"`
+				C []int   `validate:"required;max:5"`
+				D *string `validate:"required"`
+				E float64 `validate:"required"`
+				F string  `validate:"required;max:2"`
+			}{
+				F: "abc",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 6)
+				assert.Equal(t, "A", e[0].FieldName)
+				assert.Equal(t, "required", e[0].Rule)
+				assert.Equal(t, "field: A err: value is required", e[0:1].Error())
+				return true
+			},
+		},
+		{
+			name: "correct bool",
+			args: args{v: struct {
+				TermsAccepted bool   `validate:"eq:true"`
+				Banned        bool   `validate:"eq:false"`
+				Flags         []bool `validate:"eq:true"`
+			}{
+				true,
+				false,
+				[]bool{true, true},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong bool",
+			args: args{v: struct {
+				TermsAccepted bool   `validate:"eq:true"`
+				Banned        bool   `validate:"eq:false"`
+				Flags         []bool `validate:"eq:true"`
+				Bad           bool   `validate:"eq:yes"`
+			}{
+				false,
+				true,
+				[]bool{true, false},
+				true,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 4)
+				assert.Equal(t, "field: TermsAccepted err: value must be equal to true", e[0:1].Error())
+				assert.ErrorIs(t, e[3].Err, ErrInvalidValidatorSyntax)
+				return true
+			},
+		},
+		{
+			name: "correct float in",
+			args: args{v: struct {
+				A float64   `validate:"in:0.5,1.0,1.5"`
+				B float32   `validate:"in:0.1,0.2"`
+				C []float64 `validate:"in:-1,1e3"`
+			}{
+				1,
+				0.1,
+				[]float64{-1, 1000},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong float in",
+			args: args{v: struct {
+				A float64 `validate:"in:0.5,1.0,1.5"`
+				B float32 `validate:"in:0.1,0.2"`
+				C float64 `validate:"in:0.30000000000000004"`
+			}{
+				0.75,
+				0.3,
+				0.3,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 3)
+				for _, vErr := range e {
+					assert.Equal(t, "in", vErr.Rule)
+				}
+				return true
+			},
+		},
+		{
+			name: "empty in list",
+			args: args{v: struct {
+				A string `validate:"in:"`
+				B int    `validate:"in:,,"`
+				C string `validate:"in:a,b"`
+			}{
+				"",
+				0,
+				"a",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 2)
+				for _, vErr := range e {
+					assert.Equal(t, "in", vErr.Rule)
+					assert.ErrorIs(t, vErr.Err, ErrInvalidValidatorSyntax)
+				}
+				return true
+			},
+		},
+		{
+			name: "correct time",
+			args: args{v: struct {
+				A time.Time   `validate:"after:2020-01-01;before:2030-01-01"`
+				B *time.Time  `validate:"after:2020-01-01"`
+				C []time.Time `validate:"before:2030-01-01"`
+				D time.Time
+			}{
+				A: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+				C: []time.Time{time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong time",
+			args: args{v: struct {
+				A time.Time   `validate:"after:2020-01-01;before:2030-01-01"`
+				B time.Time   `validate:"after:2020-01-01;before:2030-01-01"`
+				C []time.Time `validate:"before:2030-01-01"`
+				D time.Time   `validate:"after:01.01.2020"`
+				E time.Time   `validate:"required"`
+			}{
+				A: time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC),
+				B: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+				C: []time.Time{time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2031, 5, 1, 0, 0, 0, 0, time.UTC)},
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 5)
+				assert.Equal(t, "after", e[0].Rule)
+				assert.Equal(t, "before", e[1].Rule)
+				assert.Equal(t, "C[1]", e[2].FieldName)
+				assert.ErrorIs(t, e[3].Err, ErrInvalidValidatorSyntax)
+				assert.Equal(t, "required", e[4].Rule)
+				return true
+			},
+		},
+		{
+			name: "correct map",
+			args: args{v: struct {
+				A map[string]int    `validate:"dive;min:0"`
+				B map[string]string `validate:"key=len:2;in:foo,bar"`
+				C map[int]float64   `validate:"key=max:10;dive;max:1"`
+			}{
+				map[string]int{"a": 0, "b": 10},
+				map[string]string{"aa": "foo", "bb": "bar"},
+				map[int]float64{1: 0.5, 10: 1},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong map",
+			args: args{v: struct {
+				A map[string]int    `validate:"dive;min:0"`
+				B map[string]string `validate:"key=len:2;in:foo,bar"`
+				C map[string]struct {
+					A int
+				} `validate:"dive;min:0"`
+			}{
+				map[string]int{"a": -1, "b": 10, "c": -5},
+				map[string]string{"aaa": "foo", "bb": "baz"},
+				map[string]struct{ A int }{"a": {1}},
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 5)
+				assert.Equal(t, "A[a]", e[0].FieldName)
+				assert.Equal(t, "A[c]", e[1].FieldName)
+				assert.Equal(t, "B{aaa}", e[2].FieldName)
+				assert.Equal(t, "len", e[2].Rule)
+				assert.Equal(t, "B[bb]", e[3].FieldName)
+				assert.Equal(t, "in", e[3].Rule)
+				assert.Equal(t, "C[a]", e[4].FieldName)
+				return true
+			},
+		},
+		{
+			name: "correct notin",
+			args: args{v: struct {
+				A string  `validate:"notin:admin,root"`
+				B int     `validate:"notin:0,-1"`
+				C uint    `validate:"notin:7"`
+				D float64 `validate:"notin:0.5"`
+				E string  `validate:"in:bob,root;notin:admin,root"`
+			}{
+				"bob",
+				1,
+				8,
+				0.25,
+				"bob",
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong notin",
+			args: args{v: struct {
+				A string   `validate:"notin:admin,root"`
+				B int      `validate:"notin:0,-1"`
+				C uint     `validate:"notin:7"`
+				D float64  `validate:"notin:0.5"`
+				E string   `validate:"in:bob,root;notin:admin,root"`
+				F []string `validate:"notin:admin"`
+				G int      `validate:"notin:"`
+			}{
+				"root",
+				-1,
+				7,
+				0.5,
+				"admin",
+				[]string{"bob", "admin"},
+				1,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 8)
+				assert.Equal(t, "in", e[4].Rule)
+				assert.Equal(t, "notin", e[5].Rule)
+				assert.Equal(t, "F[1]", e[6].FieldName)
+				assert.ErrorIs(t, e[7].Err, ErrInvalidValidatorSyntax)
+				return true
+			},
+		},
+		{
+			name: "correct int len",
+			args: args{v: struct {
+				A int    `validate:"len:4"`
+				B int    `validate:"len:3"`
+				C uint   `validate:"len:1"`
+				D []int  `validate:"len:2"`
+				E uint16 `validate:"len:5"`
+			}{
+				1234,
+				-123,
+				0,
+				[]int{10, -99},
+				65535,
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong int len",
+			args: args{v: struct {
+				A int     `validate:"len:4"`
+				B int     `validate:"len:3"`
+				C uint    `validate:"len:1"`
+				D []int   `validate:"len:2"`
+				E float64 `validate:"len:2"`
+			}{
+				123,
+				-1234,
+				10,
+				[]int{10, 100},
+				10,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 5)
+				assert.Equal(t, "field: A err: number of digits is 3, must be 4", e[0:1].Error())
+				assert.Equal(t, "D[1]", e[3].FieldName)
+				assert.Equal(t, "E", e[4].FieldName)
+				assert.ErrorIs(t, e[4].Err, ErrInvalidValidatorSyntax)
+				return true
+			},
+		},
+		{
+			name: "correct email",
+			args: args{v: struct {
+				A string   `validate:"email"`
+				B string   `validate:"email"`
+				C []string `validate:"email;max:30"`
+			}{
+				"user@example.com",
+				"",
+				[]string{"a@b.c", "first.last@sub.example.org"},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong email",
+			args: args{v: struct {
+				A string `validate:"email"`
+				B string `validate:"email"`
+				C string `validate:"email"`
+				D string `validate:"required;email"`
+			}{
+				"user.example.com",
+				"User <user@example.com>",
+				"user@",
+				"",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 4)
+				assert.Equal(t, "field: A err: value is not a valid email address", e[0:1].Error())
+				assert.Equal(t, "required", e[3].Rule)
+				return true
+			},
+		},
+		{
+			name: "correct url",
+			args: args{v: struct {
+				A string `validate:"url"`
+				B string `validate:"url:http,https"`
+				C string `validate:"url"`
+				D string `validate:"url:https"`
+			}{
+				"ftp://example.com/file",
+				"https://example.com:8443/hook?x=1",
+				"",
+				"HTTPS://example.com",
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong url",
+			args: args{v: struct {
+				A string `validate:"url"`
+				B string `validate:"url:http,https"`
+				C string `validate:"url"`
+				D string `validate:"url:https"`
+			}{
+				"example.com/hook",
+				"ftp://example.com/file",
+				"/relative/path",
+				"http://example.com",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 4)
+				assert.Equal(t, "field: B err: url scheme must be one of http,https", e[1:2].Error())
+				for _, vErr := range e {
+					assert.Equal(t, "url", vErr.Rule)
+				}
+				return true
+			},
+		},
+		{
+			name: "empty url scheme list",
+			args: args{v: struct {
+				A string `validate:"url:"`
+				B string `validate:"url: , "`
+			}{
+				"https://example.com",
+				"https://example.com",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 2)
+				for _, vErr := range e {
+					assert.Equal(t, "url", vErr.Rule)
+					assert.ErrorIs(t, vErr.Err, ErrInvalidValidatorSyntax)
+				}
+				assert.Equal(t, "field: A err: empty 'url' list: invalid validator syntax", e[0:1].Error())
+				return true
+			},
+		},
+		{
+			name: "correct array",
+			args: args{v: struct {
+				A [3]int    `validate:"min:1;max:5"`
+				B [2]string `validate:"len:2"`
+			}{
+				[3]int{1, 3, 5},
+				[2]string{"ab", "cd"},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong array",
+			args: args{v: struct {
+				A [3]int    `validate:"min:1;max:5"`
+				B [2]string `validate:"len:2"`
+			}{
+				[3]int{0, 3, 6},
+				[2]string{"ab", "c"},
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 3)
+				assert.Equal(t, "A[0]", e[0].FieldName)
+				assert.Equal(t, "A[2]", e[1].FieldName)
+				assert.Equal(t, "B[1]", e[2].FieldName)
+				return true
+			},
+		},
+		{
+			name: "correct slice length",
+			args: args{v: struct {
+				A []int    `validate:"minlen:1;maxlen:5"`
+				B []string `validate:"exactlen:2;len:3"`
+				C [2]int   `validate:"exactlen:2"`
+			}{
+				[]int{1, 2, 3},
+				[]string{"abc", "def"},
+				[2]int{1, 2},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong slice length",
+			args: args{v: struct {
+				A []int    `validate:"minlen:1;maxlen:5"`
+				B []int    `validate:"minlen:1;maxlen:5"`
+				C []string `validate:"exactlen:2;len:3"`
+				D []int    `validate:"minlen:-1"`
+			}{
+				[]int{},
+				[]int{1, 2, 3, 4, 5, 6},
+				[]string{"abc", "de", "fgh"},
+				nil,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 5)
+				assert.Equal(t, "minlen", e[0].Rule)
+				assert.Equal(t, "maxlen", e[1].Rule)
+				assert.Equal(t, "exactlen", e[2].Rule)
+				assert.Equal(t, "C", e[2].FieldName)
+				assert.Equal(t, "C[1]", e[3].FieldName)
+				assert.Equal(t, "minlen", e[4].Rule)
+				return true
+			},
+		},
+		{
+			name: "correct comparisons",
+			args: args{v: struct {
+				A int     `validate:"gt:0;lt:100"`
+				B float64 `validate:"gt:0;lt:1"`
+				C uint    `validate:"gt:1"`
+				D int     `validate:"gte:0;lte:10"`
+				E []int   `validate:"gt:-1"`
+			}{
+				1,
+				0.5,
+				2,
+				10,
+				[]int{0, 5},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong comparisons",
+			args: args{v: struct {
+				A int     `validate:"gt:0;lt:100"`
+				B float64 `validate:"gt:0;lt:1"`
+				C uint    `validate:"gt:1"`
+				D int     `validate:"gte:0;lte:10"`
+				E int     `validate:"lt:abc"`
+			}{
+				0,
+				1,
+				1,
+				11,
+				1,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 5)
+				assert.Equal(t, "field: A err: value is 0, must be strictly greater than 0", e[0:1].Error())
+				assert.Equal(t, "lt", e[1].Rule)
+				assert.Equal(t, "gt", e[2].Rule)
+				assert.Equal(t, "max", e[3].Rule)
+				assert.ErrorIs(t, e[4].Err, ErrInvalidValidatorSyntax)
+				return true
+			},
+		},
+		{
+			name: "correct contains",
+			args: args{v: struct {
+				A string   `validate:"contains:@"`
+				B string   `validate:"contains:a:b"`
+				C []string `validate:"contains:-"`
+			}{
+				"user@example.com",
+				"xa:b",
+				[]string{"a-b", "-"},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong contains",
+			args: args{v: struct {
+				A string   `validate:"contains:@"`
+				B string   `validate:"contains:a:b"`
+				C []string `validate:"contains:-"`
+				D string   `validate:"contains:"`
+			}{
+				"user.example.com",
+				"ab",
+				[]string{"a-b", "ab"},
+				"abc",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 4)
+				assert.Equal(t, "field: A err: value must contain '@'", e[0:1].Error())
+				assert.Equal(t, "C[1]", e[2].FieldName)
+				assert.ErrorIs(t, e[3].Err, ErrInvalidValidatorSyntax)
+				return true
+			},
+		},
+		{
+			name: "correct prefix and suffix",
+			args: args{v: struct {
+				ID   string   `validate:"prefix:usr_;min:5"`
+				File string   `validate:"suffix:.json"`
+				Both []string `validate:"prefix:a;suffix:z"`
+			}{
+				"usr_1",
+				"config.json",
+				[]string{"az", "abcz"},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong prefix and suffix",
+			args: args{v: struct {
+				ID   string `validate:"prefix:usr_;min:5"`
+				File string `validate:"suffix:.json"`
+				Both string `validate:"prefix:a;suffix:z"`
+				Bad  string `validate:"prefix:"`
+			}{
+				"usr_",
+				"config.yaml",
+				"za",
+				"abc",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 5)
+				assert.Equal(t, "min", e[0].Rule)
+				assert.Equal(t, "field: File err: value must end with '.json'", e[1:2].Error())
+				assert.Equal(t, "prefix", e[2].Rule)
+				assert.Equal(t, "suffix", e[3].Rule)
+				assert.ErrorIs(t, e[4].Err, ErrInvalidValidatorSyntax)
+				return true
+			},
+		},
+		{
+			name: "correct case-insensitive in",
+			args: args{v: struct {
+				A string   `validate:"in:red,green,blue;fold"`
+				B string   `validate:"fold;notin:admin"`
+				C []string `validate:"in:a,b;fold"`
+			}{
+				"GREEN",
+				"user",
+				[]string{"A", "b"},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong case-insensitive in",
+			args: args{v: struct {
+				A string `validate:"in:red,green,blue;fold"`
+				B string `validate:"fold;notin:admin"`
+				C string `validate:"in:red,green,blue"`
+			}{
+				"Yellow",
+				"ADMIN",
+				"Red",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 3)
+				assert.Equal(t, "in", e[0].Rule)
+				assert.Equal(t, "notin", e[1].Rule)
+				assert.Equal(t, "in", e[2].Rule)
+				return true
+			},
+		},
+		{
+			name: "correct pointer fields",
+			args: args{v: func() any {
+				i, s, e := 3, "abc", ""
+				return struct {
+					A *int    `validate:"min:1;max:5"`
+					B *string `validate:"len:3"`
+					C *int    `validate:"min:1"`
+					D *string `validate:"required;max:3"`
+					E **int   `validate:"max:5"`
+				}{&i, &s, nil, &e, func() **int { p := &i; return &p }()}
+			}()},
+			wantErr: false,
+		},
+		{
+			name: "wrong pointer fields",
+			args: args{v: func() any {
+				i, s := 10, "abcd"
+				return struct {
+					A *int    `validate:"min:1;max:5"`
+					B *string `validate:"len:3"`
+					C *int    `validate:"required;min:1"`
+					D []*int  `validate:"max:5"`
+				}{&i, &s, nil, []*int{&i}}
+			}()},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 4)
+				assert.Equal(t, "max", e[0].Rule)
+				assert.Equal(t, "len", e[1].Rule)
+				assert.Equal(t, "required", e[2].Rule)
+				assert.Equal(t, "D[0]", e[3].FieldName)
+				return true
+			},
+		},
+		{
+			name: "correct range",
+			args: args{v: struct {
+				A int     `validate:"range:1-10"`
+				B int     `validate:"range:5-"`
+				C int     `validate:"range:-10"`
+				D int     `validate:"range:-10--5"`
+				E float64 `validate:"range:0.5-1.5"`
+				F string  `validate:"range:2-3"`
+			}{
+				10,
+				100,
+				-100,
+				-7,
+				1,
+				"abc",
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong range",
+			args: args{v: struct {
+				A int `validate:"range:1-10"`
+				B int `validate:"range:5-"`
+				C int `validate:"range:-10"`
+				D int `validate:"range:-10--5"`
+				E int `validate:"range:10-1"`
+				F int `validate:"range:-"`
+				G int `validate:"range:a-b"`
+			}{
+				11,
+				4,
+				11,
+				-4,
+				1,
+				1,
+				1,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 7)
+				assert.Equal(t, "max", e[0].Rule)
+				assert.Equal(t, "min", e[1].Rule)
+				assert.Equal(t, "max", e[2].Rule)
+				assert.Equal(t, "max", e[3].Rule)
+				for _, vErr := range e[4:] {
+					assert.ErrorIs(t, vErr.Err, ErrInvalidValidatorSyntax)
+					assert.Equal(t, "range", vErr.Rule)
+				}
+				return true
+			},
+		},
+		{
+			name: "correct oneof",
+			args: args{v: struct {
+				A string `validate:"oneof=red green blue"`
+				B string `validate:"oneof:red,green"`
+				C int    `validate:"oneof=1 2 3;max:2"`
+				D string `validate:"in:red,green"`
+			}{
+				"blue",
+				"green",
+				2,
+				"red",
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong oneof",
+			args: args{v: struct {
+				A string `validate:"oneof=red green blue"`
+				B string `validate:"oneof:red, green"`
+				C int    `validate:"oneof=1 2 3"`
+				D string `validate:"oneof="`
+			}{
+				"yellow",
+				" green",
+				4,
+				"",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 4)
+				assert.Equal(t, "in", e[0].Rule)
+				assert.Equal(t, "in", e[1].Rule)
+				assert.Equal(t, "in", e[2].Rule)
+				assert.ErrorIs(t, e[3].Err, ErrInvalidValidatorSyntax)
+				return true
+			},
+		},
+		{
+			name: "len combined with min or max",
+			args: args{v: struct {
+				A string            `validate:"len:3;min:2"`
+				B string            `validate:"max:5;len:3"`
+				C []string          `validate:"len:3;min:1;max:5"`
+				D string            `validate:"len:3;in:abc"`
+				E []byte            `validate:"len:3;max:5"`
+				F map[string]int    `validate:"len:3;min:1"`
+				G map[string]string `validate:"dive;len:3;min:1"`
+				// len of integers is the number of digits
+				Year  int            `validate:"len:4;min:1900"`
+				Years []uint         `validate:"len:4;max:2100"`
+				Codes map[string]int `validate:"dive;len:3;min:100"`
+				Bytes []byte         `validate:"dive;len:2;max:200"`
+			}{
+				"abc",
+				"abc",
+				nil,
+				"abc",
+				[]byte("abc"),
+				map[string]int{"a": 1, "b": 2, "c": 3},
+				nil,
+				1999,
+				[]uint{2000},
+				map[string]int{"a": 100},
+				[]byte("ab"),
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 6)
+				for _, vErr := range e {
+					assert.Equal(t, "len", vErr.Rule)
+					assert.ErrorIs(t, vErr.Err, ErrInvalidValidatorSyntax)
+				}
+				assert.Equal(t, "A", e[0].FieldName)
+				assert.Equal(t, "E", e[3].FieldName)
+				assert.Equal(t, "F", e[4].FieldName)
+				assert.Equal(t, "G", e[5].FieldName)
+				return true
+			},
+		},
+		{
+			name: "sized ints",
+			args: args{v: struct {
+				A int8  `validate:"min:-128;max:127"`
+				B int8  `validate:"max:126"`
+				C int8  `validate:"min:-127"`
+				D int16 `validate:"in:1,2,3"`
+				E int32 `validate:"gt:0"`
+				F int64 `validate:"len:19"`
+			}{
+				-128,
+				127,
+				-128,
+				4,
+				0,
+				9223372036854775807,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 4)
+				assert.Equal(t, "B", e[0].FieldName)
+				assert.Equal(t, "max", e[0].Rule)
+				assert.Equal(t, "C", e[1].FieldName)
+				assert.Equal(t, "min", e[1].Rule)
+				assert.Equal(t, "D", e[2].FieldName)
+				assert.Equal(t, "E", e[3].FieldName)
+				return true
+			},
+		},
+		{
+			name: "sized ints at bounds",
+			args: args{v: struct {
+				A int8  `validate:"min:-128;max:127"`
+				B int8  `validate:"max:127"`
+				C int32 `validate:"notin:0"`
+			}{
+				127,
+				-128,
+				-2147483648,
+			}},
+			wantErr: false,
+		},
+		{
+			name: "unique",
+			args: args{v: struct {
+				Tags  []string `validate:"unique"`
+				IDs   [4]int   `validate:"unique;max:10"`
+				Any   []any    `validate:"unique"`
+				Empty []string `validate:"unique"`
+			}{
+				[]string{"a", "b", "a", "c", "b", "a"},
+				[4]int{1, 2, 3, 11},
+				[]any{1, "1", 1.0},
+				nil,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 3)
+				assert.Equal(t, "Tags", e[0].FieldName)
+				assert.Equal(t, "duplicate value 'a' at indices 0, 2 and 5", e[0].Err.Error())
+				assert.Equal(t, "Tags", e[1].FieldName)
+				assert.Equal(t, "unique", e[1].Rule)
+				assert.Equal(t, "duplicate value 'b' at indices 1 and 4", e[1].Err.Error())
+				assert.Equal(t, "max", e[2].Rule)
+				return true
+			},
+		},
+		{
+			name: "unique on not comparable elements",
+			args: args{v: struct {
+				A [][]int           `validate:"unique"`
+				B []any             `validate:"unique"`
+				C string            `validate:"unique"`
+				D []struct{ X any } `validate:"unique"`
+				E [][1]any          `validate:"unique"`
+				F []struct{ X any } `validate:"unique"`
+			}{
+				[][]int{{1}},
+				[]any{1, []int{1}},
+				"abc",
+				[]struct{ X any }{{[]int{1}}, {[]int{1}}},
+				[][1]any{{map[string]int{}}},
+				[]struct{ X any }{{1}, {nil}, {1}},
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 6)
+				for _, vErr := range e[:5] {
+					assert.Equal(t, "unique", vErr.Rule)
+					assert.ErrorIs(t, vErr.Err, ErrInvalidValidatorSyntax)
+				}
+				assert.Equal(t, "A", e[0].FieldName)
+				assert.Equal(t, "B", e[1].FieldName)
+				assert.Equal(t, "C", e[2].FieldName)
+				assert.Equal(t, "D", e[3].FieldName)
+				assert.Equal(t, "E", e[4].FieldName)
+				// comparable values behind interfaces are compared as usual
+				assert.Equal(t, "duplicate value '{1}' at indices 0 and 2", e[5].Err.Error())
+				return true
+			},
+		},
+		{
+			name: "in with escaped commas",
+			args: args{v: struct {
+				A string `validate:"in:Paris\\, France,Berlin"`
+				B string `validate:"in:Paris\\, France,Berlin"`
+				C string `validate:"in:Paris\\, France,Berlin"`
+				D string `validate:"notin:a\\\\,b"`
+				E string `validate:"notin:a\\\\,b"`
+			}{
+				"Paris, France",
+				"Berlin",
+				"Paris",
+				"a\\",
+				"a\\,b",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 2)
+				assert.Equal(t, "C", e[0].FieldName)
+				assert.Equal(t, "D", e[1].FieldName)
+				return true
+			},
+		},
+		{
+			name: "character classes",
+			args: args{v: struct {
+				A string `validate:"alpha"`
+				B string `validate:"alpha"`
+				C string `validate:"numeric"`
+				D string `validate:"numeric"`
+				E string `validate:"alphanumeric"`
+				F string `validate:"alphanumeric"`
+				G string `validate:"alpha;numeric;alphanumeric"`
+				H string `validate:"required;alpha"`
+			}{
+				"Привет",
+				"abc1",
+				"0123٤",
+				"-1",
+				"abc123ß",
+				"abc_123",
+				"",
+				"",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 4)
+				assert.Equal(t, "B", e[0].FieldName)
+				assert.Equal(t, "alpha", e[0].Rule)
+				assert.Equal(t, "D", e[1].FieldName)
+				assert.Equal(t, "value must contain only digits", e[1].Err.Error())
+				assert.Equal(t, "F", e[2].FieldName)
+				assert.Equal(t, "alphanumeric", e[2].Rule)
+				assert.Equal(t, "required", e[3].Rule)
+				return true
+			},
+		},
+		{
+			name: "complex magnitude",
+			args: args{v: struct {
+				A complex128 `validate:"max:5"`
+				B complex128 `validate:"max:5"`
+				C complex64  `validate:"min:1"`
+				D complex64  `validate:"gt:0;lt:1"`
+				E complex128 `validate:"in:1,2"`
+			}{
+				3 + 4i,
+				3 + 4.1i,
+				0.5i,
+				0,
+				1,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 4)
+				assert.Equal(t, "B", e[0].FieldName)
+				assert.Equal(t, "magnitude is 5.0803543183522155, can't be more than 5", e[0].Err.Error())
+				assert.Equal(t, "C", e[1].FieldName)
+				assert.Equal(t, "D", e[2].FieldName)
+				assert.Equal(t, "gt", e[2].Rule)
+				assert.Equal(t, "E", e[3].FieldName)
+				assert.ErrorIs(t, e[3].Err, ErrInvalidValidatorSyntax)
+				return true
+			},
+		},
+		{
+			name: "slices of pointers",
+			args: args{v: struct {
+				A []*int    `validate:"min:1;max:10"`
+				B []*string `validate:"len:2;in:ab,cd"`
+				C []*int    `validate:"dive;required"`
+			}{
+				[]*int{ptr(1), nil, ptr(11), ptr(0)},
+				[]*string{nil, ptr("ab"), ptr("abc")},
+				[]*int{ptr(0), nil},
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 5)
+				assert.Equal(t, "A[2]", e[0].FieldName)
+				assert.Equal(t, "max", e[0].Rule)
+				assert.Equal(t, "A[3]", e[1].FieldName)
+				assert.Equal(t, "min", e[1].Rule)
+				assert.Equal(t, "B[2]", e[2].FieldName)
+				assert.Equal(t, "len", e[2].Rule)
+				assert.Equal(t, "B[2]", e[3].FieldName)
+				assert.Equal(t, "in", e[3].Rule)
+				assert.Equal(t, "C[1]", e[4].FieldName)
+				assert.Equal(t, "required", e[4].Rule)
+				return true
+			},
+		},
+		{
+			name: "uuid",
+			args: args{v: struct {
+				A string `validate:"uuid"`
+				B string `validate:"uuid"`
+				C string `validate:"uuid"`
+				D string `validate:"uuid:v4"`
+				E string `validate:"uuid:4"`
+				F string `validate:"required;uuid"`
+				G string `validate:"uuid"`
+			}{
+				"123E4567-e89b-12d3-a456-426614174000",
+				"123e4567e89b12d3a456426614174000",
+				"123e4567-e89b-12d3-a456-42661417400g",
+				"123e4567-e89b-12d3-a456-426614174000",
+				"9b2c4c1e-5f7a-4d3b-9c8e-2a1b3c4d5e6f",
+				"",
+				"",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 4)
+				assert.Equal(t, "B", e[0].FieldName)
+				assert.Equal(t, "value is not a valid uuid", e[0].Err.Error())
+				assert.Equal(t, "C", e[1].FieldName)
+				assert.Equal(t, "D", e[2].FieldName)
+				assert.Equal(t, "uuid version must be 4", e[2].Err.Error())
+				assert.Equal(t, "required", e[3].Rule)
+				return true
+			},
+		},
+		{
+			name: "wrong uuid version",
+			args: args{v: struct {
+				A string `validate:"uuid:v9"`
+				B string `validate:"uuid:x"`
+			}{}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 2)
+				assert.ErrorIs(t, e[0].Err, ErrInvalidValidatorSyntax)
+				assert.Equal(t, "B", e[1].FieldName)
+				return true
+			},
+		},
+		{
+			name: "lexical bounds of strings",
+			args: args{v: struct {
+				A string `validate:"strmin:1.2.0"`
+				B string `validate:"strmin:1.2.0"`
+				C string `validate:"strmax:m;min:2"`
+				D string `validate:"strmin:b;strmax:d"`
+				E string `validate:"strmin:b;strmax:d"`
+			}{
+				"1.10.0",
+				"1.2.0",
+				"z",
+				"c",
+				"da",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 4)
+				// lexical order isn't the version order
+				assert.Equal(t, "A", e[0].FieldName)
+				assert.Equal(t, "strmin", e[0].Rule)
+				assert.Equal(t, "C", e[1].FieldName)
+				assert.Equal(t, "min", e[1].Rule)
+				assert.Equal(t, "C", e[2].FieldName)
+				assert.Equal(t, "strmax", e[2].Rule)
+				assert.Equal(t, "E", e[3].FieldName)
+				assert.Equal(t, "value can't be more than 'd'", e[3].Err.Error())
+				return true
+			},
+		},
+		{
+			name: "json",
+			args: args{v: struct {
+				A string `validate:"json"`
+				B string `validate:"json"`
+				C string `validate:"json"`
+				D string `validate:"json"`
+				E string `validate:"json"`
+				F string `validate:"required;json"`
+			}{
+				`{"a": [1, 2, {"b": null}]}`,
+				`[1, "2", true]`,
+				`{"a": 1,}`,
+				`{"a"`,
+				"",
+				"",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 3)
+				assert.Equal(t, "C", e[0].FieldName)
+				assert.Equal(t, "value is not a valid json", e[0].Err.Error())
+				assert.Equal(t, "D", e[1].FieldName)
+				assert.Equal(t, "F", e[2].FieldName)
+				assert.Equal(t, "required", e[2].Rule)
+				return true
+			},
+		},
+		{
+			name: "bytes",
+			args: args{v: struct {
+				A []byte          `validate:"min:2;max:4"`
+				B []byte          `validate:"len:3"`
+				C []int           `validate:"min:2;max:4"`
+				D json.RawMessage `validate:"max:2"`
+				E []byte          `validate:"maxlen:1;dive;max:100"`
+				// only min, max and len apply to a []byte as a whole, bytes are checked following dive
+				F []byte `validate:"in:1,2"`
+				G []byte `validate:"email;regexp:^a$;positive"`
+				H []byte `validate:"dive;in:1,2"`
+			}{
+				[]byte{200, 1, 0},
+				[]byte("ab"),
+				[]int{200, 1, 3},
+				json.RawMessage(`{}`),
+				[]byte{1, 200},
+				[]byte{7},
+				[]byte("b"),
+				[]byte{1, 7},
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 10)
+				assert.Equal(t, "B", e[0].FieldName)
+				assert.Equal(t, "length is 2, must be 3", e[0].Err.Error())
+				// elements of other slices are checked one by one
+				assert.Equal(t, "C[0]", e[1].FieldName)
+				assert.Equal(t, "C[1]", e[2].FieldName)
+				assert.Equal(t, "E", e[3].FieldName)
+				assert.Equal(t, "maxlen", e[3].Rule)
+				assert.Equal(t, "E[1]", e[4].FieldName)
+				assert.Equal(t, "max", e[4].Rule)
+				assert.Equal(t, "field: F err: in isn't applicable to byte slices: invalid validator syntax", e[5:6].Error())
+				for i, rule := range []string{"positive", "email", "regexp"} {
+					assert.Equal(t, "G", e[6+i].FieldName)
+					assert.Equal(t, rule, e[6+i].Rule)
+					assert.ErrorIs(t, e[6+i].Err, ErrInvalidValidatorSyntax)
+				}
+				assert.Equal(t, "H[1]", e[9].FieldName)
+				assert.Equal(t, "in", e[9].Rule)
+				return true
+			},
+		},
+		{
+			name: "positive and negative",
+			args: args{v: struct {
+				A int     `validate:"positive"`
+				B int     `validate:"positive"`
+				C int8    `validate:"negative"`
+				D int     `validate:"negative"`
+				E uint    `validate:"positive"`
+				F uint    `validate:"positive"`
+				G uint    `validate:"negative"`
+				H float64 `validate:"positive"`
+				I float32 `validate:"negative"`
+				J float64 `validate:"negative"`
+			}{
+				1, 0, -1, 0, 1, 0, 1, 0.1, -0.1, 0,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 5)
+				assert.Equal(t, "B", e[0].FieldName)
+				assert.Equal(t, "value must be positive", e[0].Err.Error())
+				assert.Equal(t, "D", e[1].FieldName)
+				assert.Equal(t, "negative", e[1].Rule)
+				assert.Equal(t, "F", e[2].FieldName)
+				assert.Equal(t, "field: G err: negative isn't applicable to unsigned integers: invalid validator syntax", e[3:4].Error())
+				assert.ErrorIs(t, e[3].Err, ErrInvalidValidatorSyntax)
+				assert.Equal(t, "J", e[4].FieldName)
+				return true
+			},
+		},
+		{
+			name: "letter case",
+			args: args{v: struct {
+				A string `validate:"lowercase"`
+				B string `validate:"lowercase"`
+				C string `validate:"uppercase"`
+				D string `validate:"uppercase"`
+				E string `validate:"lowercase;uppercase"`
+				F string `validate:"required;lowercase"`
+				G string `validate:"uppercase"`
+			}{
+				"my-slug_1",
+				"My-Slug",
+				"EUR-ÄÖ",
+				"Eur",
+				"123",
+				"",
+				"",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 3)
+				assert.Equal(t, "B", e[0].FieldName)
+				assert.Equal(t, "lowercase", e[0].Rule)
+				assert.Equal(t, "value must be in lower case", e[0].Err.Error())
+				assert.Equal(t, "D", e[1].FieldName)
+				assert.Equal(t, "uppercase", e[1].Rule)
+				assert.Equal(t, "F", e[2].FieldName)
+				assert.Equal(t, "required", e[2].Rule)
+				return true
+			},
+		},
+		{
+			name: "trimmed length",
+			args: args{v: struct {
+				A string `validate:"trim;min:1"`
+				B string `validate:"min:1"`
+				C string `validate:"trim;max:3"`
+				D string `validate:"max:3"`
+				E string `validate:"trim;len:2"`
+				F string `validate:"trim;min:2;max:4"`
+			}{
+				"   ",
+				"   ",
+				"  abc \t",
+				"  abc \t",
+				"\nab ",
+				" a ",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 3)
+				assert.Equal(t, "A", e[0].FieldName)
+				assert.Equal(t, "min", e[0].Rule)
+				assert.Equal(t, "D", e[1].FieldName)
+				assert.Equal(t, "max", e[1].Rule)
+				assert.Equal(t, "F", e[2].FieldName)
+				assert.Equal(t, "min", e[2].Rule)
+				return true
+			},
+		},
+		{
+			name: "map entry count",
+			args: args{v: struct {
+				A map[string]int    `validate:"len:3"`
+				B map[string]int    `validate:"len:1"`
+				C map[string]int    `validate:"minlen:2;maxlen:3"`
+				D map[string]int    `validate:"minlen:1;maxlen:2"`
+				E map[string]string `validate:"len:2;dive;max:3"`
+				F *map[string]int   `validate:"len:1"`
+				G map[string]int    `validate:"min:2;max:3"`
+				H map[string]int    `validate:"min:2;max:3"`
+				I map[string]uint   `validate:"len:2;dive;min:-1"`
+			}{
+				map[string]int{"a": 1, "b": 2, "c": 3},
+				map[string]int{"a": 1, "b": 2},
+				map[string]int{"a": 1},
+				map[string]int{"a": 1, "b": 2, "c": 3},
+				map[string]string{"a": "abc", "b": "abcd"},
+				&map[string]int{},
+				map[string]int{"a": 1},
+				map[string]int{"a": 1, "b": 2, "c": 3, "d": 4},
+				map[string]uint{"a": 1, "b": 2},
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 8)
+				assert.Equal(t, "B", e[0].FieldName)
+				assert.Equal(t, "len", e[0].Rule)
+				assert.Equal(t, "number of entries is 2, must be 1", e[0].Err.Error())
+				assert.Equal(t, "C", e[1].FieldName)
+				assert.Equal(t, "minlen", e[1].Rule)
+				assert.Equal(t, "D", e[2].FieldName)
+				assert.Equal(t, "maxlen", e[2].Rule)
+				assert.Equal(t, "E[b]", e[3].FieldName)
+				assert.Equal(t, "max", e[3].Rule)
+				assert.Equal(t, "F", e[4].FieldName)
+				assert.Equal(t, "field: G err: number of entries is 1, can't be less than 2", e[5:6].Error())
+				assert.Equal(t, "field: H err: number of entries is 4, can't be more than 3", e[6:7].Error())
+				// bounds following dive apply to values
+				assert.Equal(t, "I", e[7].FieldName)
+				assert.ErrorIs(t, e[7].Err, ErrInvalidValidatorSyntax)
+				return true
+			},
+		},
+		{
+			name: "multiple of",
+			args: args{v: struct {
+				A int     `validate:"multipleof:15"`
+				B int     `validate:"multipleof:15"`
+				C int     `validate:"multipleof:15"`
+				D int8    `validate:"multipleof:15"`
+				E uint    `validate:"multipleof:4"`
+				F float64 `validate:"multipleof:0.1"`
+				G float32 `validate:"multipleof:0.25"`
+				H float64 `validate:"multipleof:0.5"`
+				I int64   `validate:"multipleof:3"`
+				J int     `validate:"multipleof:0"`
+				K int     `validate:"multipleof:-5"`
+			}{
+				45,
+				0,
+				-30,
+				-20,
+				10,
+				0.3,
+				-1.75,
+				1.2,
+				1<<62 + 1,
+				0,
+				0,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 6)
+				assert.Equal(t, "D", e[0].FieldName)
+				assert.Equal(t, "multipleof", e[0].Rule)
+				assert.Equal(t, "value is -20, must be a multiple of 15", e[0].Err.Error())
+				assert.Equal(t, "E", e[1].FieldName)
+				assert.Equal(t, "value is 10, must be a multiple of 4", e[1].Err.Error())
+				assert.Equal(t, "H", e[2].FieldName)
+				assert.Equal(t, "value is 1.2, must be a multiple of 0.5", e[2].Err.Error())
+				assert.Equal(t, "I", e[3].FieldName)
+				assert.Equal(t, "J", e[4].FieldName)
+				assert.ErrorIs(t, e[4], ErrInvalidValidatorSyntax)
+				assert.Equal(t, "K", e[5].FieldName)
+				assert.ErrorIs(t, e[5], ErrInvalidValidatorSyntax)
+				return true
+			},
+		},
+		{
+			name: "multiple of steps beyond integers",
+			args: args{v: struct {
+				A int    `validate:"multipleof:1e30"`
+				B int    `validate:"multipleof:1e30"`
+				C uint64 `validate:"multipleof:1e30"`
+				D uint8  `validate:"multipleof:1e30"`
+				E int64  `validate:"multipleof:9223372036854775808"`
+				F int64  `validate:"multipleof:9223372036854775808"`
+			}{
+				0,
+				3,
+				0,
+				255,
+				math.MinInt64,
+				math.MaxInt64,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 3)
+				assert.Equal(t, "B", e[0].FieldName)
+				assert.Equal(t, "D", e[1].FieldName)
+				assert.Equal(t, "F", e[2].FieldName)
+				return true
+			},
+		},
+		{
+			name: "expected and actual values in counts and strict bounds",
+			args: args{v: struct {
+				Tags   []string          `validate:"maxlen:1"`
+				IDs    []int             `validate:"minlen:3"`
+				Pair   []int             `validate:"exactlen:2"`
+				Labels map[string]string `validate:"maxlen:0"`
+				Code   int               `validate:"len:4"`
+				Big    int64             `validate:"lt:9007199254740993"`
+				Level  uint              `validate:"gt:3"`
+				Ratio  float64           `validate:"lt:0.5"`
+				Z      complex128        `validate:"gt:5"`
+			}{
+				[]string{"a", "b"},
+				[]int{1},
+				[]int{1, 2, 3},
+				map[string]string{"a": "b"},
+				-123,
+				9007199254740993,
+				3,
+				0.5,
+				3 + 4i,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 9)
+				assert.Equal(t, "number of elements is 2, can't be more than 1", e[0].Err.Error())
+				assert.Equal(t, "number of elements is 1, can't be less than 3", e[1].Err.Error())
+				assert.Equal(t, "number of elements is 3, must be 2", e[2].Err.Error())
+				assert.Equal(t, "number of entries is 1, can't be more than 0", e[3].Err.Error())
+				assert.Equal(t, "number of digits is 3, must be 4", e[4].Err.Error())
+				assert.Equal(t, "value is 9007199254740993, must be strictly less than 9007199254740993", e[5].Err.Error())
+				assert.Equal(t, "value is 3, must be strictly greater than 3", e[6].Err.Error())
+				assert.Equal(t, "value is 0.5, must be strictly less than 0.5", e[7].Err.Error())
+				assert.Equal(t, "magnitude is 5, must be strictly greater than 5", e[8].Err.Error())
+				return true
+			},
+		},
+		{
+			name: "expected and actual values in messages",
+			args: args{v: struct {
+				Foo   string  `validate:"len:3"`
+				Name  string  `validate:"min:5;max:10"`
+				Code  string  `validate:"max:2"`
+				Data  []byte  `validate:"min:4"`
+				Age   int     `validate:"min:18"`
+				Count uint    `validate:"max:10"`
+				Ratio float64 `validate:"max:0.5"`
+			}{
+				"abcd",
+				"Жан",
+				"abc",
+				[]byte("ab"),
+				-3,
+				11,
+				0.75,
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 7)
+				assert.Equal(t, "field: Foo err: length is 4, must be 3", e[0].Error())
+				assert.Equal(t, "length is 3, can't be less than 5", e[1].Err.Error())
+				assert.Equal(t, "length is 3, can't be more than 2", e[2].Err.Error())
+				assert.Equal(t, "length is 2, can't be less than 4", e[3].Err.Error())
+				assert.Equal(t, "value is -3, can't be less than 18", e[4].Err.Error())
+				assert.Equal(t, "value is 11, can't be more than 10", e[5].Err.Error())
+				assert.Equal(t, "value is 0.75, can't be more than 0.5", e[6].Err.Error())
+				return true
+			},
+		},
+		{
+			name: "correct ip",
+			args: args{v: struct {
+				A string   `validate:"ip"`
+				B string   `validate:"ipv4"`
+				C string   `validate:"ipv6"`
+				D string   `validate:"ipv6"`
+				E []string `validate:"ip"`
+			}{
+				"2001:db8::1",
+				"192.168.0.1",
+				"::ffff:192.168.0.1",
+				"",
+				[]string{"10.0.0.1", "::1"},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong ip",
+			args: args{v: struct {
+				A string `validate:"ip"`
+				B string `validate:"ipv4"`
+				C string `validate:"ipv4"`
+				D string `validate:"ipv6"`
+				E string `validate:"required;ip"`
+			}{
+				"256.0.0.1",
+				"2001:db8::1",
+				"192.168.0",
+				"192.168.0.1",
+				"",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 5)
+				assert.Equal(t, "field: A err: value is not a valid ip address", e[0:1].Error())
+				assert.Equal(t, "field: B err: value is not a valid ipv4 address", e[1:2].Error())
+				assert.Equal(t, "ipv4", e[2].Rule)
+				assert.Equal(t, "field: C err: value is not a valid ip address", e[2:3].Error())
+				assert.Equal(t, "field: D err: value is not a valid ipv6 address", e[3:4].Error())
+				assert.Equal(t, "required", e[4].Rule)
+				return true
+			},
+		},
+		{
+			name: "correct hostname",
+			args: args{v: struct {
+				A string   `validate:"hostname"`
+				B string   `validate:"hostname"`
+				C string   `validate:"fqdn"`
+				D string   `validate:"fqdn"`
+				E string   `validate:"hostname"`
+				F []string `validate:"fqdn"`
+			}{
+				"localhost",
+				"api-1.internal.example.com",
+				"example.com",
+				"www.example.com.",
+				"",
+				[]string{"a.b", strings.Repeat("a", 63) + ".io"},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "wrong hostname",
+			args: args{v: struct {
+				A string `validate:"hostname"`
+				B string `validate:"hostname"`
+				C string `validate:"hostname"`
+				D string `validate:"hostname"`
+				E string `validate:"hostname"`
+				F string `validate:"fqdn"`
+				G string `validate:"fqdn"`
+				H string `validate:"fqdn"`
+				I string `validate:"required;fqdn"`
+			}{
+				strings.Repeat("a", 64) + ".example.com",
+				"-api.example.com",
+				"api_1.example.com",
+				"example..com",
+				"example.com.",
+				"localhost",
+				strings.Repeat(strings.Repeat("a", 63)+".", 4) + "com",
+				"exa mple.com",
+				"",
+			}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 9)
+				assert.Equal(t, "field: A err: value is not a valid hostname", e[0:1].Error())
+				for i, name := range []string{"A", "B", "C", "D", "E"} {
+					assert.Equal(t, name, e[i].FieldName)
+					assert.Equal(t, "hostname", e[i].Rule)
+				}
+				assert.Equal(t, "field: F err: value is not a valid fully qualified domain name", e[5:6].Error())
+				assert.Equal(t, "G", e[6].FieldName)
+				assert.Equal(t, "fqdn", e[7].Rule)
+				assert.Equal(t, "required", e[8].Rule)
+				return true
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.args.v)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.True(t, tt.checkErr(err), "test expect an error, but got wrong error type")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+}
+
+func TestValidationErrorsByField(t *testing.T) {
+	err := Validate(struct {
+		A string `validate:"min:3;in:abcd,efgh"`
+		B int    `validate:"max:5"`
+	}{
+		"ab",
+		6,
+	})
+
+	e := err.(ValidationErrors)
+	assert.Len(t, e.ByField("A"), 2)
+	assert.Equal(t, "min", e.ByField("A")[0].Rule)
+	assert.Equal(t, "in", e.ByField("A")[1].Rule)
+	assert.Len(t, e.ByField("B"), 1)
+	assert.Equal(t, "max", e.ByField("B")[0].Rule)
+	assert.Empty(t, e.ByField("C"))
+	assert.Equal(t, "field: B err: value is 6, can't be more than 5", e.ByField("B")[0:1].Error())
+}
+
+func TestValidateMany(t *testing.T) {
+	type order struct {
+		ID    string `validate:"len:4"`
+		Total int    `validate:"min:1"`
+	}
+
+	tests := []struct {
+		name     string
+		v        any
+		wantErr  bool
+		checkErr func(err error) bool
+	}{
+		{
+			name:    "correct slice of structs",
+			v:       []order{{"0001", 10}, {"0002", 1}},
+			wantErr: false,
+		},
+		{
+			name:    "empty slice",
+			v:       []order{},
+			wantErr: false,
+		},
+		{
+			name:    "wrong slice of structs",
+			v:       []order{{"0001", 10}, {"002", 1}, {"0003", 1}, {"0004", 0}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 2)
+				assert.Equal(t, "[1].ID", e[0].FieldName)
+				assert.Equal(t, "[3].Total", e[1].FieldName)
+				return true
+			},
+		},
+		{
+			name:    "wrong array of struct pointers",
+			v:       [3]*order{{"0001", 0}, nil, {"0003", 1}},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 2)
+				assert.Equal(t, "[0].Total", e[0].FieldName)
+				assert.Equal(t, "[1]", e[1].FieldName)
+				return errors.Is(e[1].Err, ErrNilPointer)
+			},
+		},
+		{
+			name:    "slice of non-structs",
+			v:       []int{1, 2},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrNotStruct)
+			},
+		},
+		{
+			name:    "not a slice",
+			v:       order{"0001", 1},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrNotStruct)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMany(tt.v)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.True(t, tt.checkErr(err), "test expect an error, but got wrong error type")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+type Base struct {
+	ID string `validate:"len:4"`
+}
+
+type base struct {
+	Name string `validate:"min:2"`
+}
+
+type Stamp struct {
+	At string `validate:"required"`
+}
+
+type Audit struct {
+	Stamp
+	CreatedBy string `validate:"required"`
+	UpdatedBy string
+}
+
+func TestValidateEmbedded(t *testing.T) {
+	type entity struct {
+		Base
+		*base
+		Count int `validate:"min:1"`
+	}
+
+	tests := []struct {
+		name     string
+		v        any
+		wantErr  bool
+		checkErr func(err error) bool
+	}{
+		{
+			name:    "correct embedded structs",
+			v:       entity{Base{"0001"}, &base{"abc"}, 1},
+			wantErr: false,
+		},
+		{
+			name:    "nil embedded pointer",
+			v:       entity{Base: Base{"0001"}, Count: 1},
+			wantErr: false,
+		},
+		{
+			name:    "wrong embedded structs",
+			v:       entity{Base{"001"}, &base{"a"}, 0},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 3)
+				assert.Equal(t, "ID", e[0].FieldName)
+				assert.Equal(t, "Name", e[1].FieldName)
+				assert.Equal(t, "Count", e[2].FieldName)
+				return true
+			},
+		},
+		{
+			name:    "nil embedded pointer with required fields",
+			v:       struct{ *Audit }{},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 2)
+				assert.Equal(t, "At", e[0].FieldName)
+				assert.Equal(t, "required", e[0].Rule)
+				assert.Equal(t, "value is required, but embedded Audit is nil", e[0].Err.Error())
+				assert.Equal(t, "CreatedBy", e[1].FieldName)
+				return true
+			},
+		},
+		{
+			name:    "embedded pointer with required fields",
+			v:       struct{ *Audit }{&Audit{Stamp{"2024-01-01"}, "alex", ""}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.True(t, tt.checkErr(err), "test expect an error, but got wrong error type")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateFirst(t *testing.T) {
+	v := struct {
+		A string `validate:"min:1;max:2"`
+		B int    `validate:"max:5"`
+		C struct {
+			D int `validate:"min:1"`
+		}
+	}{
+		A: "abc",
+		B: 6,
+	}
+
+	err := ValidateFirst(v)
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 1)
+	assert.Equal(t, "A", e[0].FieldName)
+	assert.Equal(t, "max", e[0].Rule)
+	assert.Equal(t, Validate(v).(ValidationErrors)[0:1].Error(), e.Error())
+
+	v.A = "ab"
+	v.B = 1
+	err = ValidateFirst(v)
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 1)
+	assert.Equal(t, "C.D", e[0].FieldName)
+
+	v.C.D = 1
+	assert.NoError(t, ValidateFirst(v))
+	assert.ErrorIs(t, ValidateFirst(1), ErrNotStruct)
+
+	// elements of slices and maps aren't checked past the first failure
+	var calls int
+	counting := New()
+	counting.RegisterValidator("odd", func(val reflect.Value) error {
+		calls++
+		if val.Int()%2 == 0 {
+			return errors.New("value must be odd")
+		}
+		return nil
+	})
+	err = counting.ValidateFirst(struct {
+		Codes  []int       `validate:"dive;odd"`
+		Prices map[int]int `validate:"dive;odd"`
+	}{make([]int, 1000), map[int]int{1: 2, 2: 2}})
+	assert.Len(t, err.(ValidationErrors), 1)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	err = counting.ValidateFirst(struct {
+		Prices map[int]int `validate:"dive;odd"`
+	}{map[int]int{1: 2, 2: 2, 3: 2}})
+	assert.Equal(t, "Prices[1]", err.(ValidationErrors)[0].FieldName)
+	assert.Equal(t, 1, calls)
+}
+
+type codeError struct {
+	code int
+}
+
+func (e codeError) Error() string {
+	return "code " + strconv.Itoa(e.code)
+}
+
+func TestValidationErrorsUnwrap(t *testing.T) {
+	RegisterValidator("code42", func(val reflect.Value) error {
+		if val.Int() != 42 {
+			return fmt.Errorf("unexpected code: %w", codeError{42})
+		}
+		return nil
+	})
+
+	err := Validate(struct {
+		A int    `validate:"max:abc"`
+		B string `validate:"min:3"`
+		C int    `validate:"code42"`
+	}{
+		1,
+		"ab",
+		1,
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+	assert.NotErrorIs(t, err, ErrNotStruct)
+
+	var cErr codeError
+	assert.ErrorAs(t, err, &cErr)
+	assert.Equal(t, 42, cErr.code)
+
+	var vErr ValidationError
+	assert.ErrorAs(t, err, &vErr)
+	assert.Equal(t, "A", vErr.FieldName)
+	assert.Equal(t, "max", vErr.Rule)
+
+	err = Validate(struct {
+		a int `validate:"max:5"`
+	}{})
+	assert.ErrorIs(t, err, ErrValidateForUnexportedFields)
+
+	err = Validate(struct {
+		A []int `validate:"max:5"`
+	}{
+		[]int{1, 2},
+	})
+	assert.NoError(t, err)
+}
+
+func TestValidateWithTag(t *testing.T) {
+	v := struct {
+		A string `validate:"max:2" check:"min:5"`
+		B int    `check:"max:10"`
+		c int    `validate:"max:1"`
+	}{
+		"abc",
+		11,
+		0,
+	}
+
+	err := ValidateWithTag(v, "check")
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 2)
+	assert.Equal(t, "A", e[0].FieldName)
+	assert.Equal(t, "min", e[0].Rule)
+	assert.Equal(t, "B", e[1].FieldName)
+	assert.Equal(t, "max", e[1].Rule)
+
+	assert.ErrorIs(t, Validate(v), ErrValidateForUnexportedFields)
+	assert.ErrorIs(t, ValidateWithTag(1, "check"), ErrNotStruct)
+}
+
+func TestValidateDive(t *testing.T) {
+	type item struct {
+		SKU   string `validate:"len:4"`
+		Price int    `validate:"min:1"`
+	}
+
+	tests := []struct {
+		name     string
+		v        any
+		wantErr  bool
+		checkErr func(err error) bool
+	}{
+		{
+			name: "correct dive",
+			v: struct {
+				Items []item   `validate:"dive;minlen:1"`
+				Refs  [2]*item `validate:"dive"`
+			}{
+				Items: []item{{"0001", 1}, {"0002", 2}},
+				Refs:  [2]*item{{"0003", 3}, nil},
+			},
+			wantErr: false,
+		},
+		{
+			name: "wrong dive",
+			v: struct {
+				Items []item `validate:"dive;minlen:3"`
+				Other []item
+			}{
+				Items: []item{{"0001", 1}, {"0002", 0}},
+				Other: []item{{"1", 0}},
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 2)
+				assert.Equal(t, "Items", e[0].FieldName)
+				assert.Equal(t, "minlen", e[0].Rule)
+				assert.Equal(t, "Items[1].Price", e[1].FieldName)
+				return true
+			},
+		},
+		{
+			name: "dive with element constraints",
+			v: struct {
+				Items []item `validate:"dive;max:3"`
+			}{},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 1)
+				assert.Equal(t, "dive", e[0].Rule)
+				return errors.Is(err, ErrInvalidValidatorSyntax)
+			},
+		},
+		{
+			name: "dive on slice of ints",
+			v: struct {
+				Items []int `validate:"dive"`
+			}{},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 1)
+				assert.Equal(t, "Items", e[0].FieldName)
+				return errors.Is(err, ErrInvalidValidatorSyntax)
+			},
+		},
+		{
+			name: "dive with slice and element constraints",
+			v: struct {
+				Tags  []string `validate:"len_min:1;len_max:2;dive;min:2;max:5"`
+				Codes []int    `validate:"minlen:1;dive;in:1,2,3;minlen:2"`
+				Names []string `validate:"len_min:1;dive;required"`
+			}{
+				Tags:  []string{"go", "a", "golang"},
+				Codes: []int{4},
+				Names: []string{""},
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 6)
+				assert.Equal(t, "Tags", e[0].FieldName)
+				assert.Equal(t, "maxlen", e[0].Rule)
+				assert.Equal(t, "Tags[1]", e[1].FieldName)
+				assert.Equal(t, "min", e[1].Rule)
+				assert.Equal(t, "Tags[2]", e[2].FieldName)
+				assert.Equal(t, "max", e[2].Rule)
+				assert.Equal(t, "Codes", e[3].FieldName)
+				assert.Equal(t, "minlen", e[3].Rule)
+				assert.Equal(t, "Codes[0]", e[4].FieldName)
+				assert.Equal(t, "in", e[4].Rule)
+				assert.Equal(t, "Names[0]", e[5].FieldName)
+				assert.Equal(t, "required", e[5].Rule)
+				return true
+			},
+		},
+		{
+			name: "element constraints before dive",
+			v: struct {
+				Tags []string `validate:"max:5;dive;min:1"`
+			}{},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				e := err.(ValidationErrors)
+				assert.Len(t, e, 1)
+				assert.Equal(t, "Tags", e[0].FieldName)
+				assert.Equal(t, "dive", e[0].Rule)
+				return errors.Is(err, ErrInvalidValidatorSyntax)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.True(t, tt.checkErr(err), "test expect an error, but got wrong error type")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseConstraintsErrors(t *testing.T) {
+	err := Validate(struct {
+		Foo string  `validate:"max:abc"`
+		Bar int     `validate:"len:-3"`
+		Baz float64 `validate:"gt:1,5"`
+	}{})
+
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	assert.Equal(t, "field: Foo err: invalid max value 'abc': invalid validator syntax", e[:1].Error())
+	assert.Equal(t, "field: Bar err: invalid len value '-3': invalid validator syntax", e[1:2].Error())
+	assert.Equal(t, "field: Baz err: invalid gt value '1,5': invalid validator syntax", e[2:].Error())
+	for _, vErr := range e {
+		assert.ErrorIs(t, vErr.Err, ErrInvalidValidatorSyntax)
+	}
+
+	// errors of tags parsed once per type get the path of every occurrence
+	type inner struct {
+		A string `validate:"min:x"`
+		B string `validate:"unknown"`
+	}
+	err = Validate(struct {
+		First  inner
+		Second *inner
+	}{Second: &inner{}})
+
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 4)
+	assert.Equal(t, "First.A", e[0].FieldName)
+	assert.Equal(t, "min", e[0].Rule)
+	assert.Equal(t, "First.B", e[1].FieldName)
+	assert.Equal(t, "unknown", e[1].Rule)
+	assert.Equal(t, "Second.A", e[2].FieldName)
+	assert.Equal(t, "Second.B", e[3].FieldName)
+	assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+}
+
+func TestValidateUnwrap(t *testing.T) {
+	type user struct {
+		Name string `validate:"min:2"`
+	}
+	valid, invalid := user{"Alex"}, user{"A"}
+
+	var nilIface any
+	var iface any = &invalid
+	var nilPtr *user
+
+	assert.NoError(t, Validate(any(valid)))
+	assert.NoError(t, Validate(any(&valid)))
+	assert.Error(t, Validate(any(invalid)))
+	assert.Error(t, Validate(any(&invalid)))
+	assert.Error(t, Validate(&iface))
+	assert.ErrorIs(t, Validate(nilIface), ErrNotStruct)
+	assert.ErrorIs(t, Validate(&nilIface), ErrNotStruct)
+	assert.ErrorIs(t, Validate(any(nilPtr)), ErrNilPointer)
+
+	err := ValidateMany([]any{valid, &invalid, nil, 1})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	assert.Equal(t, "[1].Name", e[0].FieldName)
+	assert.Equal(t, "[2]", e[1].FieldName)
+	assert.ErrorIs(t, e[1].Err, ErrNotStruct)
+	assert.Equal(t, "[3]", e[2].FieldName)
+	assert.ErrorIs(t, e[2].Err, ErrNotStruct)
+}
+
+func TestValidateDetailed(t *testing.T) {
+	res, err := ValidateDetailed(struct {
+		A string `validate:"min:3;in:abcd,efgh"`
+		B int    `validate:"max:5"`
+		C int    `validate:"max:5"`
+	}{
+		"ab",
+		6,
+		1,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"A": {"length is 2, can't be less than 3", "value is not contained in the 'in'"},
+		"B": {"value is 6, can't be more than 5"},
+	}, res)
+
+	res, err = ValidateDetailed(struct {
+		A int `validate:"max:5"`
+	}{1})
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	res, err = ValidateDetailed(struct {
+		A int `validate:"max:abc"`
+		B int `validate:"max:5"`
+	}{1, 6})
+	assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+	assert.Equal(t, map[string][]string{"B": {"value is 6, can't be more than 5"}}, res)
+
+	res, err = ValidateDetailed(1)
+	assert.ErrorIs(t, err, ErrNotStruct)
+	assert.Nil(t, res)
+}
+
+func TestValidateLenient(t *testing.T) {
+	v := struct {
+		A int `validate:"max:5"`
+		b int `validate:"max:5"`
+		c int
+		D struct {
+			e string `validate:"len:2"`
+		}
+	}{
+		A: 6,
+		b: 1,
+	}
+
+	err := ValidateLenient(v)
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	assert.Equal(t, "A", e[0].FieldName)
+	assert.Equal(t, "max", e[0].Rule)
+	assert.Equal(t, "b", e[1].FieldName)
+	assert.ErrorIs(t, e[1].Err, ErrValidateForUnexportedFields)
+	assert.Equal(t, "D.e", e[2].FieldName)
+	assert.ErrorIs(t, e[2].Err, ErrValidateForUnexportedFields)
+
+	err = Validate(v)
+	assert.Equal(t, ErrValidateForUnexportedFields.Error(), err.Error())
+}
+
+func TestValidateUnsupportedKinds(t *testing.T) {
+	v := struct {
+		A chan int `validate:"max:5"`
+		B struct {
+			C int
+		} `validate:"min:1"`
+		D func() `validate:"required"`
+		E []struct {
+			F int
+		} `validate:"len:2"`
+	}{
+		A: make(chan int),
+		D: func() {},
+		E: []struct{ F int }{{1}},
+	}
+
+	err := Validate(v)
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	assert.Equal(t, "field: A err: constraints not applicable to kind chan", e[0:1].Error())
+	assert.Equal(t, "B", e[1].FieldName)
+	assert.Equal(t, "E[0]", e[2].FieldName)
+
+	Default.IgnoreUnsupportedKinds = true
+	defer func() { Default.IgnoreUnsupportedKinds = false }()
+	assert.NoError(t, Validate(v))
+}
+
+func TestValidateConstraintsIgnoredByKind(t *testing.T) {
+	type flags struct {
+		B  bool              `validate:"min:1"`
+		N  int               `validate:"email;regexp:^a$"`
+		S  string            `validate:"gt:3;positive"`
+		T  time.Time         `validate:"max:5"`
+		P  *float64          `validate:"contains:1"`
+		L  []string          `validate:"eq:true"`
+		M  map[string]int    `validate:"len:1;dive;ipv4"`
+		K  map[int]string    `validate:"keys;uuid;endkeys"`
+		OK map[string]string `validate:"max:2;in:a,b;keys;min:2;endkeys"`
+	}
+	value := flags{M: map[string]int{"a": 1}, OK: map[string]string{"ab": "a"}}
+
+	v := New()
+	err := v.Validate(value)
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 10)
+	for _, vErr := range e {
+		assert.ErrorIs(t, vErr.Err, ErrInvalidValidatorSyntax)
+	}
+	assert.Equal(t, "field: B err: min isn't applicable to bools: invalid validator syntax", e[0:1].Error())
+	assert.Equal(t, "field: N err: email isn't applicable to integers: invalid validator syntax", e[1:2].Error())
+	assert.Equal(t, "regexp", e[2].Rule)
+	assert.Equal(t, "field: S err: gt isn't applicable to strings: invalid validator syntax", e[3:4].Error())
+	assert.Equal(t, "positive", e[4].Rule)
+	assert.Equal(t, "field: T err: max isn't applicable to times: invalid validator syntax", e[5:6].Error())
+	assert.Equal(t, "field: P err: contains isn't applicable to floats: invalid validator syntax", e[6:7].Error())
+	assert.Equal(t, "field: L err: eq isn't applicable to strings: invalid validator syntax", e[7:8].Error())
+	assert.Equal(t, "field: M err: ipv4 isn't applicable to integers: invalid validator syntax", e[8:9].Error())
+	assert.Equal(t, "field: K err: uuid isn't applicable to integers: invalid validator syntax", e[9:10].Error())
+
+	v = New()
+	v.IgnoreUnsupportedKinds = true
+	assert.NoError(t, v.Validate(value))
+}
+
+func TestValidationErrorsMarshalJSON(t *testing.T) {
+	err := Validate(struct {
+		A string `validate:"min:3"`
+		B int    `validate:"max:abc"`
+	}{
+		"ab",
+		1,
+	})
+
+	b, jsonErr := json.Marshal(err)
+	assert.NoError(t, jsonErr)
+	assert.JSONEq(t, `[
+		{"field": "A", "rule": "min", "message": "length is 2, can't be less than 3"},
+		{"field": "B", "rule": "max", "message": "invalid max value 'abc': invalid validator syntax"}
+	]`, string(b))
+
+	b, jsonErr = json.Marshal(ValidationErrors{})
+	assert.NoError(t, jsonErr)
+	assert.Equal(t, "[]", string(b))
+}
+
+func TestValidateStringLengthInRunes(t *testing.T) {
+	v := struct {
+		A string `validate:"len:4"`
+		B string `validate:"max:2"`
+		C string `validate:"min:6"`
+	}{
+		"café",
+		"👍👍",
+		"привет",
+	}
+
+	assert.NoError(t, Validate(v))
+
+	Default.CountRunes = false
+	defer func() { Default.CountRunes = true }()
+	err := Validate(v)
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 2)
+	assert.Equal(t, "A", e[0].FieldName)
+	assert.Equal(t, "B", e[1].FieldName)
+}
+
+func TestValidationErrorsGroupByField(t *testing.T) {
+	err := Validate(struct {
+		A string `validate:"min:3;in:abcd,efgh"`
+		B int    `validate:"max:5"`
+		C int    `validate:"max:abc"`
+	}{
+		"ab",
+		6,
+		1,
+	})
+
+	groups := err.(ValidationErrors).GroupByField()
+	assert.Len(t, groups, 3)
+	assert.Equal(t, "length is 2, can't be less than 3\nvalue is not contained in the 'in'", groups["A"].Error())
+	assert.Equal(t, "value is 6, can't be more than 5", groups["B"].Error())
+	assert.ErrorIs(t, groups["C"], ErrInvalidValidatorSyntax)
+}
+
+func TestValidateValue(t *testing.T) {
+	type user struct {
+		Name string `validate:"min:2"`
+	}
+	type holder struct {
+		User  user
+		Iface any
+	}
+	h := holder{User: user{"A"}, Iface: &user{"Alex"}}
+
+	err := ValidateValue(reflect.ValueOf(h).Field(0))
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 1)
+	assert.Equal(t, "Name", e[0].FieldName)
+
+	assert.NoError(t, ValidateValue(reflect.ValueOf(h).Field(1)))
+	assert.Error(t, ValidateValue(reflect.ValueOf(&h).Elem().Field(0).Addr()))
+	assert.ErrorIs(t, ValidateValue(reflect.Value{}), ErrNotStruct)
+	assert.ErrorIs(t, ValidateValue(reflect.ValueOf(1)), ErrNotStruct)
+}
+
+func TestValidatorInstances(t *testing.T) {
+	type user struct {
+		Name string `validate:"min:5" check:"max:3"`
+	}
+	v := user{"привет"}
+
+	bytes := New()
+	bytes.CountRunes = false
+	bytes.TagKey = "check"
+	err := bytes.Validate(v)
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 1)
+	assert.Equal(t, "max", e[0].Rule)
+
+	// Default isn't affected by the options of other instances
+	assert.NoError(t, Validate(v))
+	assert.NoError(t, New().Validate(v))
+}
+
+func TestValidatorCache(t *testing.T) {
+	type user struct {
+		Name string `validate:"min:2"`
+		Age  int    `validate:"max:abc"`
+	}
+
+	v := New()
+	for i := 0; i < 2; i++ {
+		err := v.Validate(user{"A", 1})
+		e := err.(ValidationErrors)
+		assert.Len(t, e, 2)
+		assert.Equal(t, "Name", e[0].FieldName)
+		assert.ErrorIs(t, e[1].Err, ErrInvalidValidatorSyntax)
+	}
+}
+
+func TestValidateDefault(t *testing.T) {
+	type config struct {
+		Host    string   `validate:"default:localhost"`
+		Port    int      `validate:"default:8080;max:9000"`
+		Ratio   *float64 `validate:"default:0.5"`
+		Debug   bool     `validate:"default:true"`
+		Retries uint8    `validate:"required;default:3"`
+	}
+
+	var c config
+	assert.NoError(t, json.Unmarshal([]byte(`{"Host":"example.com"}`), &c))
+	assert.NoError(t, Validate(&c))
+	assert.Equal(t, "example.com", c.Host)
+	assert.Equal(t, 8080, c.Port)
+	assert.Equal(t, 0.5, *c.Ratio)
+	assert.True(t, c.Debug)
+	assert.Equal(t, uint8(3), c.Retries)
+
+	// set fields are checked as usual
+	c = config{Port: 9001}
+	err := Validate(&c)
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 1)
+	assert.Equal(t, "Port", e[0].FieldName)
+
+	err = Validate(config{})
+	assert.ErrorIs(t, err, ErrNotAddressable)
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 6)
+	assert.Equal(t, "default", e[0].Rule)
+	assert.Equal(t, "required", e[5].Rule)
+
+	err = Validate(&struct {
+		A int   `validate:"default:abc"`
+		B uint8 `validate:"default:256"`
+		C []int `validate:"default:1"`
+	}{})
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	assert.ErrorIs(t, e[0].Err, ErrInvalidValidatorSyntax)
+	assert.Equal(t, "A", e[0].FieldName)
+	assert.Equal(t, "invalid default value 'abc': invalid validator syntax", e[0].Err.Error())
+}
+
+func TestValidateFieldPaths(t *testing.T) {
+	type item struct {
+		SKU string `validate:"len:4"`
+	}
+	type order struct {
+		Items  []item             `validate:"dive"`
+		Codes  [][]int            `validate:"max:9"`
+		Prices map[string]float64 `validate:"dive;min:0"`
+		Stock  map[string]int     `validate:"keys;len:2;endkeys"`
+	}
+	type customer struct {
+		Order order
+	}
+
+	err := Validate(customer{order{
+		Items:  []item{{"A001"}, {"A002"}, {"A3"}},
+		Codes:  [][]int{{1}, {2, 10}},
+		Prices: map[string]float64{"a": 1, "b": -1},
+		Stock:  map[string]int{"ab": 1, "c": 2},
+	}})
+
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 4)
+	assert.Equal(t, "Order.Items[2].SKU", e[0].FieldName)
+	assert.Equal(t, "Order.Codes[1][1]", e[1].FieldName)
+	assert.Equal(t, "Order.Prices[b]", e[2].FieldName)
+	assert.Equal(t, "Order.Stock{c}", e[3].FieldName)
+}
+
+func TestIsValid(t *testing.T) {
+	type user struct {
+		Name string `validate:"min:2"`
+	}
+
+	assert.True(t, IsValid(user{"Alex"}))
+	assert.True(t, IsValid(&user{"Alex"}))
+	assert.True(t, IsValid(struct{}{}))
+	assert.False(t, IsValid(user{"A"}))
+	assert.False(t, IsValid(1))
+	assert.False(t, IsValid((*user)(nil)))
+
+	// a failed validation is always ValidationErrors with at least one error
+	var e ValidationErrors
+	assert.True(t, errors.As(Validate(user{"A"}), &e))
+	assert.NotEmpty(t, e)
+	assert.Nil(t, Validate(struct{}{}))
+}
+
+type (
+	Age    int
+	Name   string
+	Status string
+	Score  float32
+	Tags   []Name
+)
+
+func TestValidateNamedTypes(t *testing.T) {
+	type user struct {
+		Age    Age    `validate:"min:18;max:130"`
+		Name   Name   `validate:"min:2;max:10"`
+		Status Status `validate:"in:active,blocked"`
+		Score  Score  `validate:"gt:0;lt:1"`
+		Tags   Tags   `validate:"maxlen:2;min:1;unique"`
+		Level  Age    `validate:"in:1,2,3"`
+	}
+
+	assert.NoError(t, Validate(user{18, "Alex", "active", 0.5, Tags{"a", "b"}, 2}))
+
+	err := Validate(user{17, "A", "deleted", 1, Tags{"a", "", "a"}, 4})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 8)
+	assert.Equal(t, "Age", e[0].FieldName)
+	assert.Equal(t, "Name", e[1].FieldName)
+	assert.Equal(t, "Status", e[2].FieldName)
+	assert.Equal(t, "Score", e[3].FieldName)
+	assert.Equal(t, "maxlen", e[4].Rule)
+	assert.Equal(t, "Tags", e[5].FieldName)
+	assert.Equal(t, "unique", e[5].Rule)
+	assert.Equal(t, "duplicate value 'a' at indices 0 and 2", e[5].Err.Error())
+	assert.Equal(t, "Tags[1]", e[6].FieldName)
+	assert.Equal(t, "Level", e[7].FieldName)
+}
+
+func TestValidateEach(t *testing.T) {
+	type user struct {
+		Name string `validate:"min:2"`
+	}
+	users := []*user{{"Alex"}, {"A"}, nil, {"B"}, {"Bob"}}
+
+	var indices []int
+	var errs []error
+	err := ValidateEach(users, func(index int, err error) bool {
+		indices = append(indices, index)
+		errs = append(errs, err)
+		return true
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, indices)
+	assert.NoError(t, errs[0])
+	assert.Equal(t, "Name", errs[1].(ValidationErrors)[0].FieldName)
+	assert.ErrorIs(t, errs[2], ErrNilPointer)
+	assert.Error(t, errs[3])
+	assert.NoError(t, errs[4])
+
+	// the walk stops on the first failed element
+	indices = nil
+	assert.NoError(t, ValidateEach(users, func(index int, err error) bool {
+		indices = append(indices, index)
+		return err == nil
+	}))
+	assert.Equal(t, []int{0, 1}, indices)
+
+	called := false
+	assert.ErrorIs(t, ValidateEach(user{}, func(int, error) bool {
+		called = true
+		return true
+	}), ErrNotStruct)
+	assert.False(t, called)
+}
+
+func TestValidationErrorError(t *testing.T) {
+	err := Validate(struct {
+		Name string `validate:"required"`
+		Code string `validate:"max:x"`
+	}{})
+
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 2)
+
+	var fieldErr error = e[0]
+	assert.Equal(t, "field: Name err: value is required", fieldErr.Error())
+	assert.ErrorIs(t, e[1], ErrInvalidValidatorSyntax)
+	assert.Equal(t, "value is required", ValidationError{Err: e[0].Err}.Error())
+
+	var target ValidationError
+	assert.True(t, errors.As(fmt.Errorf("wrapped: %w", e[0]), &target))
+	assert.Equal(t, "Name", target.FieldName)
+	assert.Equal(t, e[0].Error()+","+e[1].Error(), err.Error())
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestValidateEqField(t *testing.T) {
+	type signup struct {
+		Password        string `validate:"min:8"`
+		PasswordConfirm string `validate:"eqfield:Password"`
+	}
+
+	assert.NoError(t, Validate(signup{"password", "password"}))
+
+	err := Validate(signup{"password", "passw0rd"})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 1)
+	assert.Equal(t, "PasswordConfirm", e[0].FieldName)
+	assert.Equal(t, "eqfield", e[0].Rule)
+	assert.Equal(t, "value must be equal to Password", e[0].Err.Error())
+
+	// promoted fields can be referenced, unless the embedded pointer is nil
+	type account struct {
+		*signup
+		Repeat string `validate:"eqfield:Password"`
+	}
+	assert.NoError(t, Validate(account{&signup{"password", "password"}, "password"}))
+	assert.Error(t, Validate(account{&signup{"password", "password"}, "other"}))
+	assert.NoError(t, Validate(account{Repeat: "other"}))
+
+	err = Validate(struct {
+		A string `validate:"eqfield:Missing"`
+		B int    `validate:"eqfield:A"`
+		C []int  `validate:"eqfield:D"`
+		D []int
+	}{})
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	for _, vErr := range e {
+		assert.Equal(t, "eqfield", vErr.Rule)
+		assert.ErrorIs(t, vErr.Err, ErrInvalidValidatorSyntax)
+	}
+	assert.Equal(t, "A", e[0].FieldName)
+	assert.Equal(t, "B", e[1].FieldName)
+	assert.Equal(t, "C", e[2].FieldName)
+
+	// interface types are comparable, the values behind them may be not
+	type dynamic struct {
+		P any
+		C any `validate:"eqfield:P"`
+		Q [1]any
+		D [1]any `validate:"eqfield:Q"`
+	}
+	assert.NoError(t, Validate(dynamic{1, 1, [1]any{"a"}, [1]any{"a"}}))
+	assert.Error(t, Validate(dynamic{1, 2, [1]any{}, [1]any{}}))
+
+	err = Validate(dynamic{[]int{1}, []int{1}, [1]any{map[string]int{}}, [1]any{1}})
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 2)
+	assert.Equal(t, "C", e[0].FieldName)
+	assert.Equal(t, "D", e[1].FieldName)
+	assert.Equal(t, "eqfield is applicable only to comparable values: invalid validator syntax", e[0].Err.Error())
+	assert.ErrorIs(t, e[1].Err, ErrInvalidValidatorSyntax)
+}
+
+func TestValidateGtLtField(t *testing.T) {
+	type period struct {
+		StartDate time.Time
+		EndDate   time.Time `validate:"gtfield:StartDate"`
+		Min       int
+		Max       int `validate:"gtfield:Min"`
+		Mid       int `validate:"gtfield:Min;ltfield:Max"`
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, Validate(period{start, start.Add(time.Hour), 1, 10, 5}))
+
+	err := Validate(period{start, start, 5, 1, 5})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 4)
+	assert.Equal(t, "EndDate", e[0].FieldName)
+	assert.Equal(t, "gtfield", e[0].Rule)
+	assert.Equal(t, "value must be greater than StartDate", e[0].Err.Error())
+	assert.Equal(t, "Max", e[1].FieldName)
+	assert.Equal(t, "Mid", e[2].FieldName)
+	assert.Equal(t, "gtfield", e[2].Rule)
+	assert.Equal(t, "Mid", e[3].FieldName)
+	assert.Equal(t, "ltfield", e[3].Rule)
+
+	err = Validate(struct {
+		A int    `validate:"ltfield:Missing"`
+		B uint   `validate:"gtfield:A"`
+		C string `validate:"gtfield:D"`
+		D string
+	}{})
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	for _, vErr := range e {
+		assert.ErrorIs(t, vErr.Err, ErrInvalidValidatorSyntax)
+	}
+	assert.Equal(t, "ltfield", e[0].Rule)
+	assert.Equal(t, "B", e[1].FieldName)
+	assert.Equal(t, "C", e[2].FieldName)
+
+	// values of unexported fields can't be read, so they can't be referenced
+	type booking struct {
+		start time.Time
+		End   time.Time `validate:"gtfield:start"`
+	}
+	err = Validate(booking{start, start.Add(time.Hour)})
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 1)
+	assert.Equal(t, "End", e[0].FieldName)
+	assert.Equal(t, "field: End err: gtfield refers to unexported field 'start': invalid validator syntax", e.Error())
+}
+
+func TestValidateAllowUnexported(t *testing.T) {
+	type user struct {
+		Name  string `validate:"min:2"`
+		token string `validate:"len:32"`
+	}
+
+	assert.ErrorIs(t, Validate(user{"Alex", ""}), ErrValidateForUnexportedFields)
+
+	v := New()
+	v.AllowUnexported = true
+	assert.NoError(t, v.Validate(user{"Alex", ""}))
+
+	err := v.Validate(user{"A", ""})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 1)
+	assert.Equal(t, "Name", e[0].FieldName)
+
+	err = v.ValidateLenient(user{"A", ""})
+	assert.Len(t, err.(ValidationErrors), 1)
+}
+
+func TestValidateNotStructMessage(t *testing.T) {
+	var nilIface any
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{map[string]int{}, "wrong argument given, should be a struct, got map"},
+		{1, "wrong argument given, should be a struct, got int"},
+		{nil, "wrong argument given, should be a struct, got nil"},
+		{&nilIface, "wrong argument given, should be a struct, got nil interface"},
 	}
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := Validate(tt.args.v)
-			if tt.wantErr {
-				assert.Error(t, err)
-				assert.True(t, tt.checkErr(err), "test expect an error, but got wrong error type")
-			} else {
-				assert.NoError(t, err)
-			}
-		})
+		err := Validate(tt.v)
+		assert.ErrorIs(t, err, ErrNotStruct)
+		assert.EqualError(t, err, tt.want)
+	}
+
+	err := ValidateMany(nil)
+	assert.EqualError(t, err, "wrong argument given, should be a struct, got nil")
+
+	err = ValidateMany([]int{1})
+	assert.ErrorIs(t, err, ErrNotStruct)
+	assert.EqualError(t, err, "wrong argument given, should be a struct, got slice of int")
+}
+
+func TestValidateMaxErrors(t *testing.T) {
+	type item struct {
+		A int `validate:"min:1"`
+		B int `validate:"min:1"`
+	}
+	items := make([]item, 10)
+
+	v := New()
+	assert.Len(t, v.ValidateMany(items).(ValidationErrors), 20)
+
+	v.MaxErrors = 3
+	err := v.ValidateMany(items)
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 4)
+	assert.Equal(t, "[1].A", e[2].FieldName)
+	assert.Empty(t, e[3].FieldName)
+	assert.ErrorIs(t, e[3], ErrTooManyErrors)
+
+	err = v.Validate(struct {
+		Items []item `validate:"dive"`
+		Codes []int  `validate:"min:1"`
+	}{Items: items, Codes: make([]int, 10)})
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 4)
+	assert.Equal(t, "Items[1].A", e[2].FieldName)
+	assert.ErrorIs(t, e[3], ErrTooManyErrors)
+
+	// the marker appears only when errors were dropped
+	v.MaxErrors = 2
+	e = v.Validate(items[0]).(ValidationErrors)
+	assert.Len(t, e, 2)
+	assert.NotErrorIs(t, e, ErrTooManyErrors)
+
+	// elements aren't checked once the limit is reached, rather than cut off afterwards
+	v.MaxErrors = 3
+	codes, _ := v.parseTag(reflect.StructField{Type: reflect.TypeOf([]int{})}, "min:1", nil)
+	e = checkConstraints(context.Background(), reflect.ValueOf(make([]int, 100000)), "Codes", codes, v.newWalker().limit(), nil)
+	assert.Len(t, e, 4)
+	assert.Equal(t, "Codes[3]", e[3].FieldName)
+
+	ids, _ := v.parseTag(reflect.StructField{Type: reflect.TypeOf([]int{})}, "unique", nil)
+	e = checkConstraints(context.Background(), reflect.ValueOf([]int{1, 1, 2, 2, 3, 3}), "IDs", ids, 2, nil)
+	assert.Len(t, e, 2)
+
+	prices, _ := v.parseTag(reflect.StructField{Type: reflect.TypeOf(map[int]int{})}, "key=min:1;dive;min:1", nil)
+	e = checkConstraints(context.Background(), reflect.ValueOf(map[int]int{0: 0, 1: 0, 2: 0, 3: 0}), "Prices", prices, 3, nil)
+	assert.Len(t, e, 3)
+	assert.Equal(t, "Prices[1]", e[2].FieldName)
+
+	e = v.Validate(struct {
+		Codes []int `validate:"min:1"`
+	}{make([]int, 100000)}).(ValidationErrors)
+	assert.Len(t, e, 4)
+	assert.ErrorIs(t, e[3], ErrTooManyErrors)
+}
+
+func TestValidateWithTags(t *testing.T) {
+	type user struct {
+		Name  string `validate:"min:2;max:10" valid:"max:3"`
+		Email string `valid:"email"`
+		Age   int    `validate:"min:18"`
+	}
+
+	err := ValidateWithTags(user{"Alexander", "alex", 17}, "validate", "valid")
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	assert.Equal(t, "Name", e[0].FieldName)
+	assert.Equal(t, "max", e[0].Rule)
+	assert.Equal(t, "Email", e[1].FieldName)
+	assert.Equal(t, "Age", e[2].FieldName)
+
+	// the order of keys decides which max wins
+	assert.Error(t, ValidateWithTags(user{"Alexander", "alex@example.com", 18}, "validate", "valid"))
+	assert.NoError(t, ValidateWithTags(user{"Alexander", "alex@example.com", 18}, "valid", "validate"))
+	assert.NoError(t, ValidateWithTags(user{"Alexander", "alex", 17}))
+}
+
+func TestValidateSpacedTags(t *testing.T) {
+	type paint struct {
+		Color string `validate:"in: red, green , blue"`
+		Code  int    `validate:" min : 1 ; notin: 3, 4"`
+		Name  string `validate:"required ; min:1 "`
+	}
+
+	assert.NoError(t, Validate(paint{"green", 2, "a"}))
+
+	err := Validate(paint{" green ", 3, ""})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	assert.Equal(t, "Color", e[0].FieldName)
+	assert.Equal(t, "Code", e[1].FieldName)
+	assert.Equal(t, "notin", e[1].Rule)
+	assert.Equal(t, "Name", e[2].FieldName)
+
+	v := New()
+	v.PreserveSpaces = true
+	err = v.Validate(struct {
+		Color string `validate:"in: red, green"`
+	}{" green"})
+	assert.NoError(t, err)
+	err = v.Validate(struct {
+		Color string `validate:"in: red, green"`
+	}{"green"})
+	assert.Error(t, err)
+}
+
+func TestValidateField(t *testing.T) {
+	assert.NoError(t, ValidateField("alex@example.com", "required;email;max:32"))
+	assert.NoError(t, ValidateField(5, "min:1;max:10"))
+	assert.NoError(t, ValidateField([]string{"a", "b"}, "maxlen:2;unique"))
+	assert.NoError(t, ValidateField(nil, "email"))
+
+	err := ValidateField(11, "min:1;max:10")
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 1)
+	assert.Equal(t, "max", e[0].Rule)
+	assert.Empty(t, e[0].FieldName)
+	assert.EqualError(t, err, "value is 11, can't be more than 10")
+
+	err = ValidateField(nil, "required")
+	assert.Equal(t, "required", err.(ValidationErrors)[0].Rule)
+
+	for _, tag := range []string{"max:abc", "unknown", "eqfield:Other", "unique"} {
+		err = ValidateField("", tag)
+		assert.ErrorIs(t, err, ErrInvalidValidatorSyntax, tag)
+	}
+}
+
+func TestValidateOmitEmpty(t *testing.T) {
+	type item struct {
+		SKU string `validate:"len:4"`
+	}
+	type profile struct {
+		Email    string `validate:"omitempty;email"`
+		Phone    string `validate:"omitempty;required;min:5"`
+		Age      int    `validate:"omitempty;min:18"`
+		Score    *int   `validate:"omitempty;min:1"`
+		Item     item   `validate:"omitempty"`
+		Tags     []int  `validate:"omitempty;minlen:1;dive;min:1"`
+		Nickname string `validate:"email"`
+	}
+
+	err := Validate(profile{})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 1)
+	assert.Equal(t, "Phone", e[0].FieldName)
+	assert.Equal(t, "required", e[0].Rule)
+
+	// the constraints are checked as usual once the value isn't zero, a pointer to zero isn't empty
+	err = Validate(profile{"alex", "123", 17, ptr(0), item{"1"}, []int{0}, ""})
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 6)
+	assert.Equal(t, "Email", e[0].FieldName)
+	assert.Equal(t, "Phone", e[1].FieldName)
+	assert.Equal(t, "Age", e[2].FieldName)
+	assert.Equal(t, "Score", e[3].FieldName)
+	assert.Equal(t, "Item.SKU", e[4].FieldName)
+	assert.Equal(t, "Tags[0]", e[5].FieldName)
+}
+
+func TestValidateIntInList(t *testing.T) {
+	type order struct {
+		Tier   int            `validate:"in:1,x,3"`
+		Codes  []int          `validate:"in:1,2"`
+		Levels []int8         `validate:"dive;in:1,2,y"`
+		Ranks  map[string]int `validate:"key=in:a,b;in:1,2,3"`
+		Label  string         `validate:"in:a,x"`
+	}
+
+	// the list is reported once per field, with the tag, whatever the number of values
+	err := Validate(order{Tier: 5, Codes: []int{7, 8}, Levels: []int8{4, 5, 6}, Ranks: map[string]int{"c": 1}, Label: "a"})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 5)
+	assert.Equal(t, "Tier", e[0].FieldName)
+	assert.Equal(t, "in", e[0].Rule)
+	assert.ErrorIs(t, e[0], ErrInvalidValidatorSyntax)
+	assert.Equal(t, "invalid in value 'x': invalid validator syntax", e[0].Err.Error())
+	assert.Equal(t, "Codes[0]", e[1].FieldName)
+	assert.Equal(t, "Codes[1]", e[2].FieldName)
+	assert.Equal(t, "Levels", e[3].FieldName)
+	assert.ErrorIs(t, e[3], ErrInvalidValidatorSyntax)
+	assert.Equal(t, "Ranks{c}", e[4].FieldName)
+
+	_, errs := ParseConstraints(reflect.StructField{Name: "Tier", Type: reflect.TypeOf(0), Tag: `validate:"in:1,x"`}, DefaultTagKey, nil)
+	assert.Len(t, errs, 1)
+
+	err = ValidateField(4, "in:1,2,z")
+	assert.Len(t, err.(ValidationErrors), 1)
+	assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+}
+
+func TestValidateNumInLists(t *testing.T) {
+	type reading struct {
+		Port    uint16    `validate:"in:80,443"`
+		Skip    uint      `validate:"notin:0,-1"`
+		Ratio   float32   `validate:"in:0.1,0.5"`
+		Samples []float64 `validate:"notin:0,NaN"`
+		Codes   []int     `validate:"notin:1,2,x"`
+	}
+
+	err := Validate(reading{Port: 8080, Skip: 3, Ratio: 0.1, Samples: []float64{1, 0, 2}, Codes: []int{3, 4}})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 4)
+	assert.Equal(t, "Port", e[0].FieldName)
+	assert.Equal(t, "in", e[0].Rule)
+	assert.Equal(t, "Skip", e[1].FieldName)
+	assert.Equal(t, "notin", e[1].Rule)
+	assert.Equal(t, "invalid notin value '-1': invalid validator syntax", e[1].Err.Error())
+	assert.Equal(t, "Samples[1]", e[2].FieldName)
+	assert.Equal(t, "Codes", e[3].FieldName)
+	assert.ErrorIs(t, e[3], ErrInvalidValidatorSyntax)
+
+	assert.NoError(t, Validate(struct {
+		Port  uint16  `validate:"in:80,443"`
+		Ratio float32 `validate:"in:0.1,0.5"`
+	}{443, 0.5}))
+}
+
+func BenchmarkValidateIntInList(b *testing.B) {
+	ids := make([]int, 10000)
+	for i := range ids {
+		ids[i] = i % 10
+	}
+	v := struct {
+		IDs []int `validate:"in:0,1,2,3,4,5,6,7,8,9"`
+	}{ids}
+
+	for i := 0; i < b.N; i++ {
+		_ = Validate(v)
+	}
+}
+
+func TestValidateDivePointers(t *testing.T) {
+	type line struct {
+		SKU string `validate:"len:4"`
+		Qty int    `validate:"min:1"`
+	}
+	type order struct {
+		Lines    []*line  `validate:"minlen:1;dive"`
+		Required []*line  `validate:"dive;required"`
+		Fixed    [2]*line `validate:"dive"`
+	}
+
+	assert.NoError(t, Validate(order{Lines: []*line{{"A001", 1}, nil}}))
+
+	err := Validate(order{
+		Lines:    []*line{{"A001", 1}, nil, {"B", 0}},
+		Required: []*line{nil, {"C003", 0}, nil},
+		Fixed:    [2]*line{nil, {"D004", 0}},
+	})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 6)
+	assert.Equal(t, "Lines[2].SKU", e[0].FieldName)
+	assert.Equal(t, "Lines[2].Qty", e[1].FieldName)
+	assert.Equal(t, "Required[0]", e[2].FieldName)
+	assert.Equal(t, "required", e[2].Rule)
+	assert.Equal(t, "Required[1].Qty", e[3].FieldName)
+	assert.Equal(t, "Required[2]", e[4].FieldName)
+	assert.Equal(t, "Fixed[1].Qty", e[5].FieldName)
+}
+
+func TestValidateFieldNameTag(t *testing.T) {
+	type address struct {
+		ZipCode string `json:"zip_code" validate:"len:5"`
+	}
+	type user struct {
+		UserName string    `json:"user_name,omitempty" validate:"min:3"`
+		Age      int       `json:",omitempty" validate:"min:18"`
+		Secret   string    `json:"-" validate:"required"`
+		Email    string    `validate:"email"`
+		Home     address   `json:"home"`
+		Tags     []string  `json:"tags" validate:"max:2"`
+		Friends  []address `json:"friends" validate:"dive"`
+		Level    int       `json:"level" validate:"max:x"`
+	}
+	u := user{UserName: "al", Age: 16, Email: "x", Home: address{"1"}, Tags: []string{"abc"}, Friends: []address{{"12345"}, {"1"}}}
+
+	v := New()
+	v.FieldNameTag = "json"
+	err := v.Validate(u)
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 8)
+	assert.Equal(t, "user_name", e[0].FieldName)
+	assert.Equal(t, "Age", e[1].FieldName)
+	assert.Equal(t, "Secret", e[2].FieldName)
+	assert.Equal(t, "Email", e[3].FieldName)
+	assert.Equal(t, "home.zip_code", e[4].FieldName)
+	assert.Equal(t, "tags[0]", e[5].FieldName)
+	assert.Equal(t, "friends[1].zip_code", e[6].FieldName)
+	assert.Equal(t, "level", e[7].FieldName)
+	assert.ErrorIs(t, e[7], ErrInvalidValidatorSyntax)
+
+	// Go names are used by default
+	err = Validate(u)
+	e = err.(ValidationErrors)
+	assert.Equal(t, "UserName", e[0].FieldName)
+	assert.Equal(t, "Home.ZipCode", e[4].FieldName)
+
+	// a referenced field is named the same way
+	type signup struct {
+		P       string `json:"password"`
+		Confirm string `json:"confirm" validate:"eqfield:P"`
+	}
+	err = v.Validate(signup{"password", "passw0rd"})
+	assert.Equal(t, "field: confirm err: value must be equal to password", err.Error())
+}
+
+func TestValidateInvertedBounds(t *testing.T) {
+	type form struct {
+		Name  string   `validate:"min:10;max:5"`
+		Age   int      `validate:"gte:65;lte:18"`
+		Score float64  `validate:"min:0;max:1"`
+		Tags  []string `validate:"minlen:3;maxlen:1"`
+		Codes []int    `validate:"dive;min:9;max:1"`
+		Level int      `validate:"min:3;max:3"`
+	}
+
+	// the bounds are reported once and no value is checked against them
+	err := Validate(form{Name: "alex", Age: 30, Score: 0.5, Tags: []string{"a", "b"}, Codes: []int{5}, Level: 3})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 4)
+	assert.Equal(t, "Name", e[0].FieldName)
+	assert.Equal(t, "min can't be more than max: invalid validator syntax", e[0].Err.Error())
+	assert.Equal(t, "Age", e[1].FieldName)
+	assert.ErrorIs(t, e[1], ErrInvalidValidatorSyntax)
+	assert.Equal(t, "Tags", e[2].FieldName)
+	assert.Equal(t, "minlen", e[2].Rule)
+	assert.Equal(t, "Codes", e[3].FieldName)
+
+	_, errs := ParseConstraints(reflect.StructField{Name: "Name", Type: reflect.TypeOf(""), Tag: `validate:"max:5;min:10"`}, DefaultTagKey, nil)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "Name", errs[0].FieldName)
+}
+
+func TestValidateInlineStruct(t *testing.T) {
+	v := struct {
+		Name string `validate:"min:2"`
+	}{"x"}
+	p := &v
+	var boxed any = p
+
+	for _, x := range []any{v, p, &p, boxed, &boxed} {
+		err := Validate(x)
+		e := err.(ValidationErrors)
+		assert.Len(t, e, 1)
+		assert.Equal(t, "Name", e[0].FieldName)
+		assert.Equal(t, "min", e[0].Rule)
+	}
+
+	assert.NoError(t, Validate(struct {
+		Name string `validate:"min:2"`
+	}{"xy"}))
+
+	err := Validate(struct {
+		User struct {
+			Name string `validate:"min:2"`
+		}
+		Items []struct {
+			Qty int `validate:"min:1"`
+		} `validate:"dive"`
+	}{
+		User: struct {
+			Name string `validate:"min:2"`
+		}{"x"},
+		Items: []struct {
+			Qty int `validate:"min:1"`
+		}{{1}, {0}},
+	})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 2)
+	assert.Equal(t, "User.Name", e[0].FieldName)
+	assert.Equal(t, "Items[1].Qty", e[1].FieldName)
+}
+
+func TestValidateReadsFieldValues(t *testing.T) {
+	type inner struct {
+		Code string `validate:"record"`
+	}
+	type outer struct {
+		Name  string  `validate:"record"`
+		Count int     `validate:"record"`
+		Ptr   *string `validate:"record"`
+		Any   any     `validate:"record"`
+		Inner inner
+	}
+
+	var seen []any
+	v := New()
+	v.RegisterValidator("record", func(val reflect.Value) error {
+		seen = append(seen, val.Interface())
+		return nil
+	})
+
+	s := "ptr"
+	o := outer{"alex", 3, &s, 1.5, inner{"A1"}}
+	p := &o
+	var boxed any = &o
+	for _, x := range []any{o, p, &p, boxed, &boxed} {
+		seen = nil
+		assert.NoError(t, v.Validate(x))
+		assert.Equal(t, []any{"alex", 3, &s, 1.5, "A1"}, seen)
+	}
+}
+
+func TestToJoined(t *testing.T) {
+	err := Validate(struct {
+		Name string `validate:"required"`
+		Age  int    `validate:"min:x"`
+	}{Age: 1})
+
+	joined := ToJoined(err)
+	_, isValidationErrors := joined.(ValidationErrors)
+	assert.False(t, isValidationErrors)
+	assert.Len(t, joined.(interface{ Unwrap() []error }).Unwrap(), 2)
+	assert.Equal(t, "field: Name err: value is required\nfield: Age err: invalid min value 'x': invalid validator syntax", joined.Error())
+	assert.ErrorIs(t, joined, ErrInvalidValidatorSyntax)
+
+	var validationError ValidationError
+	assert.True(t, errors.As(joined, &validationError))
+	assert.Equal(t, "Name", validationError.FieldName)
+
+	assert.NoError(t, ToJoined(nil))
+	assert.Equal(t, ErrNilPointer, ToJoined(ErrNilPointer))
+}
+
+func TestValidateExplicitNested(t *testing.T) {
+	type address struct {
+		City string `validate:"required"`
+	}
+	type meta struct {
+		Source string `validate:"required"`
+	}
+	type Embedded struct {
+		ID string `validate:"required"`
+	}
+	type user struct {
+		Embedded
+		Home    address  `validate:"nested"`
+		Work    *address `validate:"nested"`
+		Billing address
+		Meta    *meta
+		Lines   []address `validate:"dive"`
+	}
+	u := user{Work: &address{}, Meta: &meta{}, Lines: []address{{}}}
+
+	// every nested struct is validated by default
+	err := Validate(u)
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 6)
+
+	v := New()
+	v.ExplicitNested = true
+	err = v.Validate(u)
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 4)
+	assert.Equal(t, "ID", e[0].FieldName)
+	assert.Equal(t, "Home.City", e[1].FieldName)
+	assert.Equal(t, "Work.City", e[2].FieldName)
+	assert.Equal(t, "Lines[0].City", e[3].FieldName)
+}
+
+func TestValidateDiveArray(t *testing.T) {
+	type Address struct {
+		City string `validate:"required"`
+		Zip  string `validate:"len:5"`
+	}
+	type user struct {
+		Addresses [2]Address  `validate:"dive"`
+		Previous  *[2]Address `validate:"dive"`
+		Empty     [0]Address  `validate:"dive"`
+	}
+
+	assert.NoError(t, Validate(user{Addresses: [2]Address{{"Paris", "75001"}, {"Lyon", "69001"}}}))
+
+	err := Validate(user{
+		Addresses: [2]Address{{"Paris", "75001"}, {"", "123"}},
+		Previous:  &[2]Address{{"Nice", "0"}, {"Lyon", "69001"}},
+	})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 3)
+	assert.Equal(t, "Addresses[1].City", e[0].FieldName)
+	assert.Equal(t, "Addresses[1].Zip", e[1].FieldName)
+	assert.Equal(t, "Previous[0].Zip", e[2].FieldName)
+}
+
+type card struct {
+	Number string `validate:"len:16"`
+}
+
+type wire struct {
+	IBAN string `validate:"required"`
+}
+
+func TestValidateInterfaceFields(t *testing.T) {
+	type payment struct {
+		Method  any `validate:"nested"`
+		Backup  any `validate:"nested"`
+		Note    any `validate:"nested"`
+		Missing any `validate:"nested"`
+		Raw     any
+	}
+
+	assert.NoError(t, Validate(payment{Method: card{"1234567812345678"}, Backup: &wire{"DE89"}, Note: "text"}))
+
+	err := Validate(payment{Method: card{"1234"}, Backup: &wire{}, Note: 5, Raw: card{"1"}})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 2)
+	assert.Equal(t, "Method.(validator.card).Number", e[0].FieldName)
+	assert.Equal(t, "len", e[0].Rule)
+	assert.Equal(t, "Backup.(*validator.wire).IBAN", e[1].FieldName)
+
+	v := New()
+	v.ExplicitNested = true
+	assert.Len(t, v.Validate(payment{Method: card{"1234"}}).(ValidationErrors), 1)
+}
+
+func TestValidateCustomSeparators(t *testing.T) {
+	type event struct {
+		Time  string   `validate:"required|regexp=^\\d\\d:\\d\\d$"`
+		City  string   `validate:"in=Paris, France;Berlin, Germany"`
+		Tag   string   `validate:"in=a\\;b;c"`
+		Level int      `validate:"min=1|max=3"`
+		Codes []string `validate:"minlen=1|dive|oneof=x;y z"`
+	}
+
+	v := New()
+	v.ConstraintSep, v.KVSep, v.ListSep = "|", "=", ";"
+
+	assert.NoError(t, v.Validate(event{"10:30", "Berlin, Germany", "a;b", 2, []string{"x", "z"}}))
+
+	err := v.Validate(event{"10;30", "Paris", "a", 4, []string{"w"}})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 5)
+	assert.Equal(t, "Time", e[0].FieldName)
+	assert.Equal(t, "regexp", e[0].Rule)
+	assert.Equal(t, "City", e[1].FieldName)
+	assert.Equal(t, "Tag", e[2].FieldName)
+	assert.Equal(t, "Level", e[3].FieldName)
+	assert.Equal(t, "max", e[3].Rule)
+	assert.Equal(t, "Codes[0]", e[4].FieldName)
+
+	// the default separators don't recognize such tags
+	assert.ErrorIs(t, Validate(event{}), ErrInvalidValidatorSyntax)
+}
+
+func TestMustValidate(t *testing.T) {
+	type config struct {
+		Port int `validate:"min:1;max:65535"`
+	}
+
+	assert.NotPanics(t, func() { MustValidate(config{8080}) })
+
+	defer func() {
+		e, ok := recover().(ValidationErrors)
+		assert.True(t, ok)
+		assert.Len(t, e, 1)
+		assert.Equal(t, "Port", e[0].FieldName)
+	}()
+	assert.PanicsWithError(t, "wrong argument given, should be a struct, got int", func() { MustValidate(1) })
+	MustValidate(config{0})
+	t.Error("MustValidate didn't panic")
+}
+
+func TestValidateMapKeysBlock(t *testing.T) {
+	type inventory struct {
+		Stock  map[string]int `validate:"keys;min:2;max:4;endkeys;dive;min:0"`
+		Prices map[int]string `validate:"keys;gt:0;endkeys;len:3;dive;required"`
+		Codes  map[string]int `validate:"keys;alpha;endkeys;dive;min:1"`
+	}
+
+	assert.NoError(t, Validate(inventory{
+		Stock:  map[string]int{"ab": 0, "abcd": 5},
+		Prices: map[int]string{1: "a", 2: "b", 3: "c"},
+		Codes:  map[string]int{"eur": 1},
+	}))
+
+	// invalid keys and invalid values are reported independently
+	err := Validate(inventory{
+		Stock:  map[string]int{"a": 1, "abcde": 2, "ab": -1},
+		Prices: map[int]string{-1: "a", 2: "", 3: "c"},
+		Codes:  map[string]int{"e1": 0},
+	})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 7)
+	assert.Equal(t, "Stock{a}", e[0].FieldName)
+	assert.Equal(t, "min", e[0].Rule)
+	assert.Equal(t, "Stock[ab]", e[1].FieldName)
+	assert.Equal(t, "min", e[1].Rule)
+	assert.Equal(t, "Stock{abcde}", e[2].FieldName)
+	assert.Equal(t, "max", e[2].Rule)
+	assert.Equal(t, "Prices{-1}", e[3].FieldName)
+	assert.Equal(t, "gt", e[3].Rule)
+	assert.Equal(t, "Prices[2]", e[4].FieldName)
+	assert.Equal(t, "required", e[4].Rule)
+	assert.Equal(t, "Codes{e1}", e[5].FieldName)
+	assert.Equal(t, "alpha", e[5].Rule)
+	assert.Equal(t, "Codes[e1]", e[6].FieldName)
+
+	err = Validate(struct {
+		A map[string]int `validate:"keys;min:2"`
+		B map[string]int `validate:"maxlen:2;endkeys"`
+	}{})
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 2)
+	assert.Equal(t, "keys", e[0].Rule)
+	assert.Equal(t, "endkeys", e[1].Rule)
+
+	// key constraints of other kinds have no keys to check
+	err = Validate(struct {
+		S string       `validate:"keys;min:2;endkeys"`
+		N []int        `validate:"key=max:3"`
+		M *map[int]int `validate:"key=max:3"`
+	}{"a", nil, nil})
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 2)
+	assert.Equal(t, "field: S err: key constraints are applicable only to maps: invalid validator syntax", e[0:1].Error())
+	assert.Equal(t, "N", e[1].FieldName)
+	assert.Equal(t, "keys", e[1].Rule)
+}
+
+type uniqueIDs struct {
+	IDs []int `validate:"unique"`
+}
+
+func TestValidateUniqueIndices(t *testing.T) {
+	err := ValidateField([]int{7, 3, 7, 3, 7, 1}, "unique")
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 2)
+	assert.Equal(t, "duplicate value '7' at indices 0, 2 and 4", e[0].Err.Error())
+	assert.Equal(t, "duplicate value '3' at indices 1 and 3", e[1].Err.Error())
+
+	// elements reached through an unexported embedded struct are compared one by one
+	type order struct {
+		uniqueIDs
+	}
+	err = Validate(order{uniqueIDs{[]int{1, 2, 3, 2, 1}}})
+	e = err.(ValidationErrors)
+	assert.Len(t, e, 2)
+	assert.Equal(t, "IDs", e[0].FieldName)
+	assert.Equal(t, "duplicate value '1' at indices 0 and 4", e[0].Err.Error())
+	assert.Equal(t, "duplicate value '2' at indices 1 and 3", e[1].Err.Error())
+}
+
+func TestValidateMinusOneBounds(t *testing.T) {
+	type temperature struct {
+		Low   int     `validate:"min:-1"`
+		High  int     `validate:"max:-1"`
+		Range int     `validate:"range:-1-5"`
+		Delta float64 `validate:"range:-5--1"`
+	}
+
+	assert.NoError(t, Validate(temperature{-1, -1, -1, -1}))
+
+	err := Validate(temperature{-2, 0, -5, -0.5})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 4)
+	assert.Equal(t, "field: Low err: value is -2, can't be less than -1", e[0:1].Error())
+	assert.Equal(t, "field: High err: value is 0, can't be more than -1", e[1:2].Error())
+	assert.Equal(t, "Range", e[2].FieldName)
+	assert.Equal(t, "min", e[2].Rule)
+	assert.Equal(t, "Delta", e[3].FieldName)
+	assert.Equal(t, "max", e[3].Rule)
+}
+
+func TestValidateLargeIntBounds(t *testing.T) {
+	type ids struct {
+		A int64  `validate:"max:9007199254740992"`
+		B uint64 `validate:"min:18446744073709551615"`
+		C int64  `validate:"gt:9007199254740993"`
+		D int64  `validate:"range:-9007199254740993-1e30"`
+	}
+
+	assert.NoError(t, Validate(ids{9007199254740992, math.MaxUint64, 9007199254740994, -9007199254740993}))
+
+	err := Validate(ids{9007199254740993, math.MaxUint64 - 1, 9007199254740993, -9007199254740994})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 4)
+	assert.Equal(t, "field: A err: value is 9007199254740993, can't be more than 9007199254740992", e[0:1].Error())
+	assert.Equal(t, "field: B err: value is 18446744073709551614, can't be less than 18446744073709551615", e[1:2].Error())
+	assert.Equal(t, "gt", e[2].Rule)
+	assert.Equal(t, "D", e[3].FieldName)
+	assert.Equal(t, "min", e[3].Rule)
+}
+
+func TestValidateInapplicableConstraints(t *testing.T) {
+	type measures struct {
+		Weights []float64    `validate:"len:2"`
+		Counts  []uint       `validate:"min:-5"`
+		Total   uint         `validate:"min:-1;max:10"`
+		Levels  []uint16     `validate:"negative"`
+		Phases  []complex64  `validate:"len:1"`
+		Signals []complex128 `validate:"in:1,2;notin:3"`
 	}
 
+	err := Validate(measures{[]float64{1, 2, 3}, []uint{1, 2, 3}, 11, []uint16{1, 2}, []complex64{1, 2, 3}, []complex128{1, 3}})
+	e := err.(ValidationErrors)
+	assert.Len(t, e, 8)
+	assert.Equal(t, "field: Weights err: len isn't applicable to floats: invalid validator syntax", e[0:1].Error())
+	assert.Equal(t, "field: Counts err: min -5 isn't applicable to unsigned integers: invalid validator syntax", e[1:2].Error())
+	assert.Equal(t, "min", e[2].Rule)
+	assert.ErrorIs(t, e[2].Err, ErrInvalidValidatorSyntax)
+	// the other bounds are still checked
+	assert.Equal(t, "field: Total err: value is 11, can't be more than 10", e[3:4].Error())
+	assert.Equal(t, "Levels", e[4].FieldName)
+	assert.Equal(t, "negative", e[4].Rule)
+	assert.Equal(t, "field: Phases err: len isn't applicable to complex numbers: invalid validator syntax", e[5:6].Error())
+	assert.Equal(t, "Signals", e[6].FieldName)
+	assert.Equal(t, "in", e[6].Rule)
+	assert.Equal(t, "Signals", e[7].FieldName)
+	assert.Equal(t, "notin", e[7].Rule)
+
+	// the type is unknown when the tag is parsed for another field
+	c, errs := Default.parseTag(reflect.StructField{Name: "Any", Type: reflect.TypeOf((*any)(nil)).Elem()}, "len:2", nil)
+	assert.Empty(t, errs)
+	e = CheckConstraints(reflect.ValueOf(1.5), "Any", c, nil)
+	assert.Len(t, e, 1)
+	assert.ErrorIs(t, e[0].Err, ErrInvalidValidatorSyntax)
 }